@@ -0,0 +1,212 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/certify"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/report"
+	"github.com/rohanelukurthy/rig-rank/internal/scoring"
+	"github.com/rohanelukurthy/rig-rank/internal/telemetry"
+	"github.com/rohanelukurthy/rig-rank/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// exitNotQualified mirrors `check`: 2 means the machine was measured and fell short.
+const exitNotQualified = 2
+
+type certifyOptions struct {
+	policy      string
+	results     []string
+	output      string
+	format      string
+	signedBy    string
+	signKey     string
+	scoringPath string
+
+	contextWindow int
+	energy        bool
+}
+
+func newCertifyCmd() *cobra.Command {
+	opts := certifyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "certify",
+		Short: "Check this machine against a hardware qualification policy",
+		Long: `Benchmarks every model named in a qualification policy and judges each requirement
+(minimum use-case ratings or metric floors) as pass or fail. The report is sealed with a
+SHA-256 digest and, with --sign-key, an Ed25519 signature. Exits 0 when qualified, 2 when
+not and 1 on errors.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			runCertify(opts, commandProvenance(cmd))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.policy, "policy", "p", "", "Qualification policy JSON file (required)")
+	flags.StringArrayVar(&opts.results, "results", nil, "Use an existing results file from this machine for its model instead of running it (repeatable)")
+	flags.StringVarP(&opts.output, "output", "o", "", "Path to save the qualification report JSON")
+	flags.StringVarP(&opts.format, "format", "f", "text", "Output format: text or json")
+	flags.StringVar(&opts.signedBy, "signed-off-by", "", "Name recorded in the report's sign-off")
+	flags.StringVar(&opts.signKey, "sign-key", "", "Ed25519 private key (PEM) to sign the report with")
+	flags.StringVar(&opts.scoringPath, "scoring", "", "Path to a scoring policy JSON file used for ratings (defaults to the built-in policy)")
+	flags.IntVarP(&opts.contextWindow, "context-window", "c", 4096, "Context window size for the models")
+	flags.BoolVar(&opts.energy, "energy", false, "Sample CPU and GPU energy while each profile runs")
+
+	cmd.MarkFlagRequired("policy")
+	cmd.AddCommand(newCertifyVerifyCmd(), newCertifyKeygenCmd())
+	return cmd
+}
+
+func runCertify(opts certifyOptions, provenance *models.Provenance) {
+	policy, err := certify.LoadPolicy(opts.policy)
+	if err != nil {
+		fatal(err)
+	}
+	scoringPolicy := scoring.DefaultPolicy()
+	if opts.scoringPath != "" {
+		if scoringPolicy, err = scoring.LoadPolicy(opts.scoringPath); err != nil {
+			fatal(err)
+		}
+	}
+	var key ed25519.PrivateKey
+	if opts.signKey != "" {
+		if key, err = certify.LoadPrivateKey(opts.signKey); err != nil {
+			fatal(err)
+		}
+	}
+
+	sys, err := telemetry.GetSystemInfo()
+	if err != nil {
+		fatal(err)
+	}
+
+	results := make(map[string]*models.FullReport)
+	for _, path := range opts.results {
+		r, err := report.Load(path)
+		if err != nil {
+			fatal(err)
+		}
+		// Results from another machine can't qualify this one
+		if err := certify.CheckHardware(sys, r); err != nil {
+			fatal(fmt.Errorf("%s: %w", path, err))
+		}
+		// Re-rate with the chosen scoring policy so every model is judged the same way
+		r.UseCaseSuitability = scoringPolicy.Evaluate(r.InferenceResults)
+		results[r.InferenceResults.ModelMetadata.Name] = r
+	}
+
+	runErrors := make(map[string]error)
+	for _, model := range policy.Models() {
+		if results[model] != nil {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Benchmarking %s...\n", model)
+		r, err := ui.RunHeadless(ui.Options{
+			ModelName:     model,
+			ContextWindow: opts.contextWindow,
+			Energy:        opts.energy,
+			Policy:        scoringPolicy,
+			Provenance:    provenance,
+		}, os.Stderr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s failed: %v\n", model, err)
+			runErrors[model] = err
+			continue
+		}
+		results[model] = r
+	}
+
+	now := time.Now()
+	qualification := certify.Evaluate(policy, sys, results, runErrors, now)
+	if err := certify.Sign(qualification, opts.signedBy, key, now); err != nil {
+		fatal(err)
+	}
+
+	data, err := json.MarshalIndent(qualification, "", "  ")
+	if err != nil {
+		fatal(err)
+	}
+	if opts.output != "" {
+		if err := os.WriteFile(opts.output, data, 0644); err != nil {
+			fatal(fmt.Errorf("failed to write qualification report: %w", err))
+		}
+		fmt.Fprintf(os.Stderr, "Qualification report saved to %s\n", opts.output)
+	}
+
+	switch opts.format {
+	case "text":
+		fmt.Print(ui.RenderCertification(qualification))
+	case "json":
+		fmt.Println(string(data))
+	default:
+		fatal(fmt.Errorf("unknown format %q (want text or json)", opts.format))
+	}
+
+	if !qualification.Qualified {
+		os.Exit(exitNotQualified)
+	}
+}
+
+func newCertifyVerifyCmd() *cobra.Command {
+	var publicKey string
+
+	cmd := &cobra.Command{
+		Use:   "verify <report.json>",
+		Short: "Check that a qualification report hasn't changed since it was signed off",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			r, err := certify.LoadReport(args[0])
+			if err != nil {
+				fatal(err)
+			}
+			var trusted ed25519.PublicKey
+			if publicKey != "" {
+				if trusted, err = certify.LoadPublicKey(publicKey); err != nil {
+					fatal(err)
+				}
+			}
+			if err := certify.Verify(r, trusted); err != nil {
+				fatal(err)
+			}
+			// Only a signature from a key supplied out of band proves who signed off
+			switch {
+			case r.SignOff.Algorithm == certify.AlgorithmDigest:
+				fmt.Fprintln(os.Stderr, "Warning: this report has a digest but no signature. Anyone can edit it and recompute the digest.")
+				fmt.Printf("%s: matches its digest (sha256: unsigned digest only, not tamper-proof), qualified=%v\n", args[0], r.Qualified)
+			case trusted == nil:
+				fmt.Fprintln(os.Stderr, "Warning: the signature was checked against the public key embedded in the report, so anyone could have re-signed it. Pass --public-key with the signer's key to trust it.")
+				fmt.Printf("%s: signature matches its embedded key, signer NOT verified (ed25519), qualified=%v\n", args[0], r.Qualified)
+			default:
+				fmt.Printf("%s: intact, signed by the trusted key (ed25519), qualified=%v\n", args[0], r.Qualified)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&publicKey, "public-key", "", "Require the report to be signed by this Ed25519 public key (PEM); without it the signer is not verified")
+	return cmd
+}
+
+func newCertifyKeygenCmd() *cobra.Command {
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "keygen",
+		Short: "Create an Ed25519 key pair for signing qualification reports",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := certify.GenerateKey(out+".pem", out+".pub.pem"); err != nil {
+				fatal(err)
+			}
+			fmt.Fprintf(os.Stderr, "Wrote %s.pem (keep private) and %s.pub.pem\n", out, out)
+		},
+	}
+
+	cmd.Flags().StringVar(&out, "out", "rigrank-signing", "File name prefix for the key pair")
+	return cmd
+}
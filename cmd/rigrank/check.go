@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/compare"
+	"github.com/rohanelukurthy/rig-rank/internal/junit"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/report"
+	"github.com/rohanelukurthy/rig-rank/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// exitRegression is the exit code when the gate fails, distinct from 1 for errors, so
+// CI can tell a slower rig from a broken run.
+const exitRegression = 2
+
+var checkFormats = []string{"text", "json", "junit"}
+
+type checkOptions struct {
+	baseline         string
+	tolerance        string
+	metricTolerances []string
+	metrics          []string
+	from             string
+	update           bool
+	format           string
+	output           string
+
+	model         string
+	contextWindow int
+	think         bool
+	rawSamples    bool
+	energy        bool
+	timelineMs    int
+
+	trace traceOptions
+}
+
+func newCheckCmd() *cobra.Command {
+	opts := checkOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Run the suite and fail if it regressed against a baseline (for CI)",
+		Long: `Runs the benchmark suite without the interactive UI and compares each metric to a
+baseline results file. Exits 0 when every metric is within tolerance, 2 on regression
+and 1 on errors. With --update-baseline the new results replace the baseline instead.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			runCheck(opts, commandProvenance(cmd))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.baseline, "baseline", "b", "", "Baseline results JSON file (required)")
+	flags.StringVarP(&opts.tolerance, "tolerance", "t", "10%", "Allowed slowdown for every metric")
+	flags.StringArrayVar(&opts.metricTolerances, "metric-tolerance", nil, "Per-metric tolerance, e.g. ttft_ms=20% or code_gen.gen_tps=5% (repeatable)")
+	flags.StringSliceVar(&opts.metrics, "metrics", compare.DefaultCheckMetrics, "Metrics to gate")
+	flags.StringVar(&opts.from, "from", "", "Check an existing results file instead of running the suite")
+	flags.BoolVar(&opts.update, "update-baseline", false, "Write the new results to the baseline file instead of gating")
+	flags.StringVarP(&opts.format, "format", "f", "text", "Output format: text, json or junit")
+	flags.StringVarP(&opts.output, "output", "o", "", "Also save the new results JSON to this path")
+
+	flags.StringVarP(&opts.model, "model", "m", "", "Ollama model name (defaults to the baseline's model)")
+	flags.IntVarP(&opts.contextWindow, "context-window", "c", 4096, "Context window size for the model")
+	flags.BoolVar(&opts.think, "think", false, "Enable thinking mode on the Reasoning profile")
+	flags.BoolVar(&opts.rawSamples, "raw-samples", false, "Keep every iteration's raw timings in the JSON output")
+	flags.BoolVar(&opts.energy, "energy", false, "Sample CPU and GPU energy while each profile runs")
+	flags.IntVar(&opts.timelineMs, "timeline-interval-ms", 1000, "Resource timeline sampling interval in milliseconds (0 disables)")
+
+	addTraceFlags(flags, &opts.trace)
+
+	cmd.MarkFlagRequired("baseline")
+	return cmd
+}
+
+func runCheck(opts checkOptions, provenance *models.Provenance) {
+	if !slices.Contains(checkFormats, opts.format) {
+		fatal(fmt.Errorf("unknown format %q (want one of %s)", opts.format, strings.Join(checkFormats, ", ")))
+	}
+	if err := compare.ValidateMetrics(opts.metrics); err != nil {
+		fatal(err)
+	}
+
+	tol := compare.Tolerances{PerMetric: make(map[string]float64)}
+	var err error
+	if tol.Default, err = compare.ParsePercent(opts.tolerance); err != nil {
+		fatal(err)
+	}
+	for _, mt := range opts.metricTolerances {
+		key, pct, err := compare.ParseMetricTolerance(mt)
+		if err != nil {
+			fatal(err)
+		}
+		tol.PerMetric[key] = pct
+	}
+
+	baseline, err := report.Load(opts.baseline)
+	if err != nil && !(opts.update && errors.Is(err, os.ErrNotExist)) {
+		fatal(err)
+	}
+
+	flushTraces := startTracing(opts.trace, provenance)
+	current, err := checkResults(opts, baseline, provenance)
+	flushTraces()
+	if err != nil {
+		fatal(err)
+	}
+	data, err := json.MarshalIndent(current, "", "  ")
+	if err != nil {
+		fatal(err)
+	}
+	if opts.output != "" {
+		if err := os.WriteFile(opts.output, data, 0644); err != nil {
+			fatal(fmt.Errorf("failed to write results: %w", err))
+		}
+	}
+
+	if opts.update {
+		if err := os.WriteFile(opts.baseline, data, 0644); err != nil {
+			fatal(fmt.Errorf("failed to update baseline: %w", err))
+		}
+		fmt.Fprintf(os.Stderr, "Baseline %s updated\n", opts.baseline)
+		return
+	}
+
+	result, err := compare.Check(baseline, current, opts.metrics, tol)
+	if err != nil {
+		fatal(err)
+	}
+	result.Baseline = opts.baseline
+
+	switch opts.format {
+	case "text":
+		fmt.Print(ui.RenderCheck(result))
+	case "json":
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Println(string(out))
+	case "junit":
+		if err := junit.Write(os.Stdout, junit.FromReport(current, result)); err != nil {
+			fatal(err)
+		}
+	}
+
+	if !result.Passed {
+		os.Exit(exitRegression)
+	}
+}
+
+// checkResults loads the results to gate, or runs the suite headlessly to produce them.
+func checkResults(opts checkOptions, baseline *models.FullReport, provenance *models.Provenance) (*models.FullReport, error) {
+	if opts.from != "" {
+		return report.Load(opts.from)
+	}
+
+	model := opts.model
+	if model == "" && baseline != nil {
+		model = baseline.InferenceResults.ModelMetadata.Name
+	}
+	if model == "" {
+		return nil, fmt.Errorf("--model is required when there is no baseline to take it from")
+	}
+
+	return ui.RunHeadless(ui.Options{
+		ModelName:     model,
+		ContextWindow: opts.contextWindow,
+		Energy:        opts.energy,
+		TimelineEvery: time.Duration(opts.timelineMs) * time.Millisecond,
+		Think:         opts.think,
+		RawSamples:    opts.rawSamples,
+		Provenance:    provenance,
+	}, os.Stderr)
+}
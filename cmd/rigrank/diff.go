@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rohanelukurthy/rig-rank/internal/compare"
+	"github.com/rohanelukurthy/rig-rank/internal/report"
+	"github.com/rohanelukurthy/rig-rank/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+type diffOptions struct {
+	format string
+	alpha  float64
+}
+
+func newDiffCmd() *cobra.Command {
+	opts := diffOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "diff <base.json> <head.json>",
+		Short: "Compare two result files and flag statistically meaningful changes",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			runDiff(opts, args[0], args[1])
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.format, "format", "f", "text", "Output format: text, markdown or json")
+	flags.Float64Var(&opts.alpha, "alpha", compare.DefaultAlpha, "Significance level for the Welch's t-test")
+
+	return cmd
+}
+
+func runDiff(opts diffOptions, basePath, headPath string) {
+	base, err := report.Load(basePath)
+	if err != nil {
+		fatal(err)
+	}
+	head, err := report.Load(headPath)
+	if err != nil {
+		fatal(err)
+	}
+
+	d := compare.Compare(base, head, opts.alpha)
+	d.Base, d.Head = basePath, headPath
+
+	switch opts.format {
+	case "text":
+		fmt.Print(ui.RenderDiff(d))
+	case "markdown", "md":
+		fmt.Print(ui.RenderDiffMarkdown(d))
+	case "json":
+		data, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Println(string(data))
+	default:
+		fatal(fmt.Errorf("unknown format %q (want text, markdown or json)", opts.format))
+	}
+}
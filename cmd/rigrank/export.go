@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/rohanelukurthy/rig-rank/internal/export"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/report"
+	"github.com/spf13/cobra"
+)
+
+type exportOptions struct {
+	format      string
+	output      string
+	fromHistory bool
+	noHeader    bool
+	details     bool
+}
+
+func newExportCmd() *cobra.Command {
+	opts := exportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export [results.json]...",
+		Short: "Flatten result files into rows for spreadsheets and DuckDB, or render them as a report",
+		Long: `Flattens one or more results files into tidy long-format rows: run ID, hardware,
+model, profile, metric, statistic and value. All files go into a single table,
+so results from many machines can be analysed together. Runs without a recorded
+run ID are named after their file or history entry.
+
+With --format html, markdown or junit the files are rendered as one report
+instead. Markdown opens with a side-by-side comparison when given several files.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 && !opts.fromHistory {
+				fatal(fmt.Errorf("give at least one results file, or --history"))
+			}
+			runExport(opts, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.format, "format", "f", export.FormatCSV, "Export format: "+strings.Join(exportFormats(), ", "))
+	flags.StringVarP(&opts.output, "output", "o", "", "Write to this file instead of stdout")
+	flags.BoolVar(&opts.fromHistory, "history", false, "Also export every run in the local history store")
+	flags.BoolVar(&opts.noHeader, "no-header", false, "Leave out the CSV header, e.g. when appending to an existing file")
+	flags.BoolVar(&opts.details, "details", false, "With --format markdown, add the raw statistics in a collapsible block")
+
+	return cmd
+}
+
+func exportFormats() []string {
+	return append(slices.Clone(export.Formats), reportFormats...)
+}
+
+func runExport(opts exportOptions, paths []string) {
+	if !slices.Contains(exportFormats(), opts.format) {
+		fatal(fmt.Errorf("unknown format %q (want one of %s)", opts.format, strings.Join(exportFormats(), ", ")))
+	}
+	if opts.format == export.FormatParquet && opts.output == "" {
+		fatal(errParquetStdout)
+	}
+
+	var reports []*models.FullReport
+	var rows []export.Row
+	for _, path := range paths {
+		r, err := report.Load(path)
+		if err != nil {
+			fatal(err)
+		}
+		reports = append(reports, r)
+		rows = append(rows, export.Rows(r, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))...)
+	}
+	if opts.fromHistory {
+		store := openHistory()
+		entries, err := store.List()
+		if err != nil {
+			fatal(err)
+		}
+		for _, e := range entries {
+			r, _, err := store.Load(e.ID)
+			if err != nil {
+				fatal(err)
+			}
+			reports = append(reports, r)
+			rows = append(rows, export.Rows(r, e.ID)...)
+		}
+	}
+
+	out := os.Stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			fatal(fmt.Errorf("failed to create %s: %w", opts.output, err))
+		}
+		out = f
+	}
+	var summary string
+	if slices.Contains(reportFormats, opts.format) {
+		page, err := renderReports(opts.format, reports, opts.details)
+		if err != nil {
+			fatal(err)
+		}
+		if _, err := out.Write(page); err != nil {
+			fatal(err)
+		}
+		summary = fmt.Sprintf("Rendered %d runs", len(reports))
+	} else {
+		if err := export.Write(out, opts.format, rows, !opts.noHeader); err != nil {
+			fatal(err)
+		}
+		summary = fmt.Sprintf("Exported %d rows", len(rows))
+	}
+	if opts.output != "" {
+		// Parquet writes its footer on flush, so a full disk may only show up here
+		if err := out.Close(); err != nil {
+			fatal(fmt.Errorf("failed to write %s: %w", opts.output, err))
+		}
+		fmt.Fprintf(os.Stderr, "%s to %s\n", summary, opts.output)
+	}
+}
+
+// errParquetStdout refuses binary Parquet output on a terminal or pipe.
+var errParquetStdout = errors.New("--format parquet writes a binary file; give its path with --output")
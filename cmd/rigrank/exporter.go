@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/export"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+type exporterOptions struct {
+	models        []string
+	listen        string
+	interval      time.Duration
+	contextWindow int
+	think         bool
+	energy        bool
+	noHistory     bool
+
+	trace traceOptions
+}
+
+func newExporterCmd() *cobra.Command {
+	opts := exporterOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "exporter",
+		Short: "Benchmark periodically and serve the results to Prometheus on /metrics",
+		Long: `Runs the suite for each model now and then every --interval, without the interactive
+UI, and serves the latest results of every model on /metrics in the OpenMetrics
+format. A failed run keeps the model's previous results and counts towards
+rigrank_exporter_runs_total{result="failure"}.
+
+For a one-off run, "rigrank run --format openmetrics" writes the same metrics for
+the node_exporter textfile collector instead.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			runExporter(opts, commandProvenance(cmd))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVarP(&opts.models, "model", "m", nil, "Ollama models to benchmark, in order (required, repeatable)")
+	flags.StringVar(&opts.listen, "listen", ":9197", "Address to serve /metrics on")
+	flags.DurationVar(&opts.interval, "interval", 6*time.Hour, "Time between the end of one round of runs and the start of the next")
+	flags.IntVarP(&opts.contextWindow, "context-window", "c", 4096, "Context window size for the models")
+	flags.BoolVar(&opts.think, "think", false, "Enable thinking mode on the Reasoning profile")
+	flags.BoolVar(&opts.energy, "energy", false, "Sample CPU and GPU energy while each profile runs")
+	flags.BoolVar(&opts.noHistory, "no-history", false, "Don't save the runs to the local history store")
+	addTraceFlags(flags, &opts.trace)
+
+	cmd.MarkFlagRequired("model")
+	return cmd
+}
+
+func runExporter(opts exporterOptions, provenance *models.Provenance) {
+	if opts.interval <= 0 {
+		fatal(fmt.Errorf("--interval must be positive"))
+	}
+
+	// Spans are sent in the background as each run ends; the exporter never exits
+	// cleanly, so there is nothing to flush.
+	startTracing(opts.trace, provenance)
+
+	collector := export.NewCollector()
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", collector)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	ln, err := net.Listen("tcp", opts.listen)
+	if err != nil {
+		fatal(err)
+	}
+	go func() {
+		fatal(srv.Serve(ln))
+	}()
+	fmt.Fprintf(os.Stderr, "Serving metrics on %s/metrics\n", ln.Addr())
+
+	for {
+		for _, model := range opts.models {
+			fmt.Fprintf(os.Stderr, "Benchmarking %s\n", model)
+			report, err := ui.RunHeadless(ui.Options{
+				ModelName:     model,
+				ContextWindow: opts.contextWindow,
+				Energy:        opts.energy,
+				Think:         opts.think,
+				Provenance:    provenance,
+			}, os.Stderr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %s failed: %v\n", model, err)
+			} else if !opts.noHistory {
+				saveToHistory(report)
+			}
+			collector.Record(model, report, err, time.Now())
+		}
+
+		fmt.Fprintf(os.Stderr, "Next run at %s\n", time.Now().Add(opts.interval).Format(time.Kitchen))
+		time.Sleep(opts.interval)
+	}
+}
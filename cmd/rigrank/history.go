@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rohanelukurthy/rig-rank/internal/history"
+	"github.com/rohanelukurthy/rig-rank/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List, show and remove past runs stored on this machine",
+	}
+	cmd.AddCommand(newHistoryListCmd(), newHistoryShowCmd(), newHistoryRmCmd())
+	return cmd
+}
+
+func newHistoryListCmd() *cobra.Command {
+	var model, fingerprint string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stored runs, oldest first",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			store := openHistory()
+			entries, err := store.List()
+			if err != nil {
+				fatal(err)
+			}
+
+			var filtered []history.Entry
+			for _, e := range entries {
+				if model != "" && e.Model != model {
+					continue
+				}
+				if fingerprint != "" && !strings.HasPrefix(e.Fingerprint, fingerprint) {
+					continue
+				}
+				filtered = append(filtered, e)
+			}
+			if limit > 0 && len(filtered) > limit {
+				filtered = filtered[len(filtered)-limit:]
+			}
+
+			fmt.Print(ui.RenderHistory(filtered))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&model, "model", "m", "", "Only list runs of this model")
+	flags.StringVar(&fingerprint, "hardware", "", "Only list runs on this hardware fingerprint")
+	flags.IntVarP(&limit, "limit", "n", 0, "Only list the N most recent runs")
+	return cmd
+}
+
+func newHistoryShowCmd() *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show the report card of a stored run (IDs may be abbreviated)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			report, entry, err := openHistory().Load(args[0])
+			if err != nil {
+				fatal(err)
+			}
+
+			if asJSON {
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					fatal(err)
+				}
+				fmt.Println(string(data))
+				return
+			}
+
+			fmt.Printf("Run %s at %s on %s\n", entry.ID, entry.Timestamp.Format("2006-01-02 15:04 MST"), entry.Hardware)
+			if report.InferenceResults != nil && report.UseCaseSuitability != nil {
+				fmt.Print(ui.RenderChart(report.UseCaseSuitability, report.InferenceResults))
+			}
+			fmt.Print(ui.RenderResourceSpikes(report.ResourceTimeline))
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the stored JSON report instead of the report card")
+	return cmd
+}
+
+func newHistoryRmCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rm <id>...",
+		Short: "Remove stored runs",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			store := openHistory()
+			for _, id := range args {
+				entry, err := store.Remove(id)
+				if err != nil {
+					fatal(err)
+				}
+				fmt.Fprintf(os.Stderr, "Removed %s (%s)\n", entry.ID, entry.Model)
+			}
+		},
+	}
+}
+
+func openHistory() *history.Store {
+	store, err := history.Open()
+	if err != nil {
+		fatal(err)
+	}
+	return store
+}
@@ -16,11 +16,27 @@ func main() {
 
 func newRootCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "rigrank",
-		Short: "RigRank - Local LLM Benchmark Tool",
-		Long:  `RigRank checks your hardware capabilities and benchmarks standard LLMs locally via Ollama.`,
+		Use:     "rigrank",
+		Short:   "RigRank - Local LLM Benchmark Tool",
+		Long:    `RigRank checks your hardware capabilities and benchmarks standard LLMs locally via Ollama.`,
+		Version: buildInfo().Version,
 	}
 
 	cmd.AddCommand(newRunCmd())
+	cmd.AddCommand(newRecommendCmd())
+	cmd.AddCommand(newHistoryCmd())
+	cmd.AddCommand(newDiffCmd())
+	cmd.AddCommand(newCheckCmd())
+	cmd.AddCommand(newCertifyCmd())
+	cmd.AddCommand(newValidateCmd())
+	cmd.AddCommand(newMigrateCmd())
+	cmd.AddCommand(newExportCmd())
+	cmd.AddCommand(newExporterCmd())
 	return cmd
 }
+
+// fatal reports an error the way every subcommand does and exits.
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
+}
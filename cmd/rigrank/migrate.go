@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/report"
+	"github.com/spf13/cobra"
+)
+
+type migrateOptions struct {
+	inPlace bool
+	output  string
+}
+
+func newMigrateCmd() *cobra.Command {
+	opts := migrateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "migrate <results.json>...",
+		Short: "Upgrade result files written by older versions to the current layout",
+		Long: `Upgrades results files to the current metrics_version. Values the older version
+never recorded are written as explicit nulls. Every command that reads results
+already does this on the fly; migrate rewrites the files so other tools can read
+them too.
+
+With one file and no flags the upgraded JSON is printed to stdout.`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runMigrate(opts, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opts.inPlace, "in-place", "w", false, "Rewrite each file in place")
+	flags.StringVarP(&opts.output, "output", "o", "", "Write the upgraded file here (one input only)")
+
+	return cmd
+}
+
+func runMigrate(opts migrateOptions, paths []string) {
+	switch {
+	case opts.inPlace && opts.output != "":
+		fatal(fmt.Errorf("use either --in-place or --output, not both"))
+	case len(paths) > 1 && !opts.inPlace:
+		fatal(fmt.Errorf("migrating several files needs --in-place"))
+	}
+
+	stillInvalid := false
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fatal(fmt.Errorf("failed to read results: %w", err))
+		}
+		out, from, err := report.Migrate(data)
+		if err != nil {
+			fatal(fmt.Errorf("%s: %w", path, err))
+		}
+
+		dest := opts.output
+		if opts.inPlace {
+			dest = path
+		}
+		if from == models.MetricsVersion {
+			fmt.Fprintf(os.Stderr, "%s: already at metrics_version %s\n", path, from)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s: migrated metrics_version %s → %s\n", path, from, models.MetricsVersion)
+		}
+
+		// Migration can't repair files that were malformed to begin with
+		var verr *report.ValidationError
+		if err := report.Validate(out); errors.As(err, &verr) {
+			fmt.Fprintf(os.Stderr, "Warning: %s still breaks the schema: %v\n", path, verr)
+			stillInvalid = true
+		}
+
+		switch {
+		case dest == "":
+			os.Stdout.Write(out)
+		case from == models.MetricsVersion && dest == path:
+			// Nothing to rewrite
+		default:
+			if err := os.WriteFile(dest, out, 0644); err != nil {
+				fatal(fmt.Errorf("failed to write %s: %w", dest, err))
+			}
+		}
+	}
+
+	if stillInvalid {
+		os.Exit(exitInvalid)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rohanelukurthy/rig-rank/internal/benchmark"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/recommend"
+	"github.com/rohanelukurthy/rig-rank/internal/scoring"
+	"github.com/rohanelukurthy/rig-rank/internal/telemetry"
+	"github.com/rohanelukurthy/rig-rank/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+type recommendOptions struct {
+	top           int
+	benchmark     int
+	contextWindow int
+	output        string
+}
+
+func newRecommendCmd() *cobra.Command {
+	opts := recommendOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "recommend",
+		Short: "Predict which models fit this machine and optionally benchmark the best ones",
+		Run: func(cmd *cobra.Command, args []string) {
+			runRecommend(opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.IntVarP(&opts.top, "top", "n", 15, "Number of candidates to show (0 shows all)")
+	flags.IntVar(&opts.benchmark, "benchmark", 0, "Benchmark the top N installed candidates that fit")
+	flags.IntVarP(&opts.contextWindow, "context-window", "c", 4096, "Context window size used when benchmarking")
+	flags.StringVarP(&opts.output, "output", "o", "", "Path to save recommendations as JSON")
+
+	return cmd
+}
+
+// recommendReport is the JSON form of the recommendations.
+type recommendReport struct {
+	SystemInfo  *models.SystemInfo                   `json:"system_info"`
+	Budget      recommend.Budget                     `json:"budget"`
+	Candidates  []recommend.Candidate                `json:"candidates"`
+	Results     map[string]*models.BenchmarkResult   `json:"results,omitempty"`
+	Suitability map[string]*models.SuitabilityReport `json:"suitability,omitempty"`
+}
+
+func runRecommend(opts recommendOptions) {
+	sys, err := telemetry.GetSystemInfo()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to read system info: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Installed models are optional; without Ollama the catalogue alone is still useful
+	client := benchmark.NewClient(benchmark.DefaultOllamaURL)
+	installed, err := client.ListModels()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not list installed models (%v); showing the catalogue only\n", err)
+	}
+
+	candidates := recommend.Recommend(sys, installed)
+	if opts.top > 0 && len(candidates) > opts.top {
+		candidates = candidates[:opts.top]
+	}
+
+	report := recommendReport{
+		SystemInfo:  sys,
+		Budget:      recommend.BudgetFor(sys),
+		Candidates:  candidates,
+		Results:     make(map[string]*models.BenchmarkResult),
+		Suitability: make(map[string]*models.SuitabilityReport),
+	}
+
+	for _, c := range candidates {
+		if len(report.Results) >= opts.benchmark {
+			break
+		}
+		if !c.Installed || c.Fit == recommend.FitNone {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Benchmarking %s...\n", c.Name)
+		runner := benchmark.NewRunner(client, opts.contextWindow)
+		result, err := runner.RunSuite(c.Name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: benchmark of %s failed: %v\n", c.Name, err)
+			continue
+		}
+		if info := benchmark.FindModel(installed, c.Name); info != nil {
+			result.ModelMetadata = info.Metadata()
+		}
+		report.Results[c.Name] = result
+		report.Suitability[c.Name] = scoring.Evaluate(result)
+	}
+
+	fmt.Fprintln(os.Stderr, ui.RenderRecommendations(report.Budget, candidates, report.Suitability))
+
+	if opts.output != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := os.WriteFile(opts.output, data, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Recommendations saved to %s\n", opts.output)
+	}
+}
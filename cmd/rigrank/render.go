@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/rohanelukurthy/rig-rank/internal/junit"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/ui"
+)
+
+// reportFormats render whole reports as documents, unlike export.Formats which
+// flatten them into rows.
+var reportFormats = []string{"html", "markdown", "junit"}
+
+// renderReports renders one or more reports as a single document. Details adds the
+// raw statistics where the format supports it.
+func renderReports(format string, reports []*models.FullReport, details bool) ([]byte, error) {
+	switch format {
+	case "html":
+		page, err := ui.RenderHTML(reports...)
+		return []byte(page), err
+	case "markdown":
+		return []byte(ui.RenderMarkdown(reports, details)), nil
+	case "junit":
+		var suites []junit.Suite
+		for _, r := range reports {
+			suites = append(suites, junit.FromReport(r, nil))
+		}
+		var buf bytes.Buffer
+		err := junit.Write(&buf, suites...)
+		return buf.Bytes(), err
+	default:
+		return nil, fmt.Errorf("unknown report format %q", format)
+	}
+}
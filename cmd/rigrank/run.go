@@ -1,11 +1,21 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rohanelukurthy/rig-rank/internal/export"
+	"github.com/rohanelukurthy/rig-rank/internal/history"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/publish"
+	"github.com/rohanelukurthy/rig-rank/internal/scoring"
 	"github.com/rohanelukurthy/rig-rank/internal/telemetry"
 	"github.com/rohanelukurthy/rig-rank/internal/ui"
 	"github.com/spf13/cobra"
@@ -15,12 +25,28 @@ type runOptions struct {
 	model         string
 	debug         bool
 	output        string
+	format        string
+	details       bool
 	contextWindow int
 	quietWait     bool
 	quietCPU      float64
 	quietRAMMB    uint64
 	quietTimeout  int
 	quietWaitSecs int
+	energy        bool
+	timelineMs    int
+	think         bool
+	rawSamples    bool
+	scoringPath   string
+	tasksPath     string
+	noHistory     bool
+
+	publish        []string
+	publishHeaders []string
+	publishTimeout time.Duration
+	publishRetries int
+
+	trace traceOptions
 }
 
 func newRunCmd() *cobra.Command {
@@ -30,7 +56,7 @@ func newRunCmd() *cobra.Command {
 		Use:   "run",
 		Short: "Execute the standard benchmark suite",
 		Run: func(cmd *cobra.Command, args []string) {
-			runBenchmark(opts)
+			runBenchmark(opts, commandProvenance(cmd))
 		},
 	}
 
@@ -38,6 +64,8 @@ func newRunCmd() *cobra.Command {
 	flags.StringVarP(&opts.model, "model", "m", "llama3", "Ollama model name to benchmark")
 	flags.BoolVarP(&opts.debug, "debug", "d", false, "Enable verbose debug logging")
 	flags.StringVarP(&opts.output, "output", "o", "", "Path to save JSON results")
+	flags.StringVarP(&opts.format, "format", "f", "json", "Results format: json, csv, parquet, openmetrics, html, markdown or junit")
+	flags.BoolVar(&opts.details, "details", false, "With --format markdown, add the raw statistics in a collapsible block")
 	flags.IntVarP(&opts.contextWindow, "context-window", "c", 4096, "Context window size for the model")
 
 	flags.BoolVar(&opts.quietWait, "quiet-wait", false, "Wait for system to become idle before benchmarking")
@@ -46,11 +74,65 @@ func newRunCmd() *cobra.Command {
 	flags.IntVar(&opts.quietTimeout, "quiet-timeout", 60, "Timeout in seconds to wait for quiet state")
 	flags.IntVar(&opts.quietWaitSecs, "quiet-wait-secs", 5, "Duration in seconds of sustained quiet state required")
 
+	flags.StringVar(&opts.scoringPath, "scoring", "", "Path to a scoring policy JSON file (defaults to the built-in policy)")
+	flags.StringVar(&opts.tasksPath, "tasks", "", "Path to a task catalogue JSON file for task-time estimates (defaults to the built-in tasks)")
+	flags.BoolVar(&opts.think, "think", false, "Enable thinking mode on the Reasoning profile (deepseek-r1, qwen3, ...)")
+	flags.BoolVar(&opts.rawSamples, "raw-samples", false, "Keep every iteration's raw timings in the JSON output")
+	flags.BoolVar(&opts.noHistory, "no-history", false, "Don't save this run to the local history store")
+	flags.BoolVar(&opts.energy, "energy", true, "Sample CPU (RAPL) and GPU (nvidia-smi) energy while each profile runs")
+	flags.IntVar(&opts.timelineMs, "timeline-interval-ms", 1000, "Resource timeline sampling interval in milliseconds (0 disables)")
+
+	flags.StringArrayVar(&opts.publish, "publish", nil, "Publish the results to a sink: influx (stdout), influx=FILE or webhook=URL (repeatable)")
+	flags.StringArrayVar(&opts.publishHeaders, "publish-header", nil, `Header for webhook sinks, e.g. "Authorization: Bearer $TOKEN" (repeatable)`)
+	flags.DurationVar(&opts.publishTimeout, "publish-timeout", 30*time.Second, "Timeout for each webhook attempt")
+	flags.IntVar(&opts.publishRetries, "publish-retries", 3, "Webhook retries after a network error, 429 or 5xx")
+	markSensitive(flags, "publish", "publish-header")
+	addTraceFlags(flags, &opts.trace)
+
 	return cmd
 }
 
-func runBenchmark(opts runOptions) {
-	// 3. Output
+func runBenchmark(opts runOptions, provenance *models.Provenance) {
+	sinks, err := parseSinks(opts.publish, opts.publishHeaders, publish.WebhookOptions{Timeout: opts.publishTimeout, Retries: opts.publishRetries})
+	if err != nil {
+		fatal(err)
+	}
+	for _, s := range sinks {
+		// Line protocol mixed into the results would corrupt both
+		if lp, ok := s.(*publish.LineProtocolSink); ok && lp.Stdout() && opts.output == "" {
+			fatal(fmt.Errorf("--publish influx writes to stdout, where the results go too; save them with --output, or use influx=FILE"))
+		}
+	}
+
+	formats := append(append([]string{"json"}, export.Formats...), reportFormats...)
+	if !slices.Contains(formats, opts.format) {
+		fatal(fmt.Errorf("unknown format %q (want one of %s)", opts.format, strings.Join(formats, ", ")))
+	}
+	if opts.format == export.FormatParquet && opts.output == "" {
+		fatal(errParquetStdout)
+	}
+
+	policy := scoring.DefaultPolicy()
+	if opts.scoringPath != "" {
+		var err error
+		policy, err = scoring.LoadPolicy(opts.scoringPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	tasks := scoring.DefaultTasks()
+	if opts.tasksPath != "" {
+		var err error
+		tasks, err = scoring.LoadTasks(opts.tasksPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	flushTraces := startTracing(opts.trace, provenance)
 
 	quietCfg := telemetry.QuietStateConfig{
 		Timeout:      time.Duration(opts.quietTimeout) * time.Second,
@@ -59,8 +141,23 @@ func runBenchmark(opts runOptions) {
 		RAMMinFreeMB: opts.quietRAMMB,
 	}
 
-	p := tea.NewProgram(ui.NewModel(opts.model, opts.debug, opts.output, opts.contextWindow, opts.quietWait, quietCfg), tea.WithOutput(os.Stderr))
+	p := tea.NewProgram(ui.NewModel(ui.Options{
+		ModelName:     opts.model,
+		Debug:         opts.debug,
+		OutputPath:    opts.output,
+		ContextWindow: opts.contextWindow,
+		QuietWait:     opts.quietWait,
+		QuietCfg:      quietCfg,
+		Energy:        opts.energy,
+		TimelineEvery: time.Duration(opts.timelineMs) * time.Millisecond,
+		Think:         opts.think,
+		RawSamples:    opts.rawSamples,
+		Policy:        policy,
+		Tasks:         tasks,
+		Provenance:    provenance,
+	}), tea.WithOutput(os.Stderr))
 	m, err := p.Run()
+	flushTraces()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Alas, there's been an error: %v\n", err)
 		os.Exit(1)
@@ -86,17 +183,95 @@ func runBenchmark(opts runOptions) {
 			fmt.Fprintln(os.Stderr, view)
 		}
 
-		// 2. Handle JSON Data
+		if report := finalModel.Report(); report != nil && !opts.noHistory {
+			saveToHistory(report)
+		}
+		if report := finalModel.Report(); report != nil && len(sinks) > 0 {
+			if err := publish.Publish(context.Background(), report, sinks); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not publish results: %v\n", err)
+			}
+		}
+
+		// 2. Handle JSON Data, a rendered report, or its flattened export
+		if report := finalModel.Report(); report != nil && slices.Contains(reportFormats, opts.format) {
+			if jsonBytes, err = renderReports(opts.format, []*models.FullReport{report}, opts.details); err != nil {
+				fatal(err)
+			}
+		} else if report != nil && opts.format != "json" {
+			var buf bytes.Buffer
+			if err := export.Write(&buf, opts.format, export.Rows(report, ""), true); err != nil {
+				fatal(err)
+			}
+			jsonBytes = buf.Bytes()
+		}
 		if opts.output != "" {
 			// Write to file
-			if err := os.WriteFile(opts.output, jsonBytes, 0644); err != nil {
+			if err := writeFileAtomic(opts.output, jsonBytes); err != nil {
 				fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
 			} else {
 				fmt.Fprintf(os.Stderr, "Results saved to %s\n", opts.output)
 			}
 		} else {
 			// Print to Stdout for piping
-			fmt.Println(string(jsonBytes))
+			os.Stdout.Write(jsonBytes)
+			if opts.format == "json" {
+				fmt.Println()
+			}
+		}
+	}
+}
+
+// writeFileAtomic replaces path in one step, so a scraper such as the node_exporter
+// textfile collector never reads a half-written file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// parseSinks builds the --publish sinks, sharing the headers among the webhooks.
+func parseSinks(specs, headers []string, webhook publish.WebhookOptions) ([]publish.Sink, error) {
+	webhook.Headers = make(map[string]string)
+	for _, h := range headers {
+		name, value, err := publish.ParseHeader(h)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Headers[name] = value
+	}
+	var sinks []publish.Sink
+	for _, spec := range specs {
+		sink, err := publish.ParseSink(spec, webhook)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+// saveToHistory records a finished run. Failing to save never fails the run itself.
+func saveToHistory(report *models.FullReport) {
+	store, err := history.Open()
+	if err == nil {
+		var entry history.Entry
+		if entry, err = store.Save(report, time.Now()); err == nil {
+			fmt.Fprintf(os.Stderr, "Saved to history as %s\n", entry.ID)
+			return
 		}
 	}
+	fmt.Fprintf(os.Stderr, "Warning: could not save run to history: %v\n", err)
 }
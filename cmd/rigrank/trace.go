@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/tracing"
+	"github.com/spf13/pflag"
+)
+
+// traceFlushTimeout bounds how long a finished run waits for its spans to be sent.
+const traceFlushTimeout = 10 * time.Second
+
+type traceOptions struct {
+	endpoint string
+	file     string
+}
+
+func addTraceFlags(flags *pflag.FlagSet, opts *traceOptions) {
+	flags.StringVar(&opts.endpoint, "trace-endpoint", "", "Send OpenTelemetry traces to this OTLP/HTTP endpoint, e.g. http://localhost:4318")
+	flags.StringVar(&opts.file, "trace-file", "", "Append OpenTelemetry traces to this file as OTLP-JSON lines")
+	markSensitive(flags, "trace-endpoint")
+}
+
+// startTracing installs the trace exporters and returns the function that flushes
+// them, which must run before the process exits. A failure to flush only warns.
+func startTracing(opts traceOptions, provenance *models.Provenance) (flush func()) {
+	shutdown, err := tracing.Setup(context.Background(), tracing.Options{
+		Endpoint: opts.endpoint,
+		File:     opts.file,
+		Version:  provenance.RigRank.Version,
+		Hostname: provenance.Host.Hostname,
+	})
+	if err != nil {
+		fatal(err)
+	}
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not export traces: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/report"
+	"github.com/spf13/cobra"
+)
+
+// exitInvalid mirrors `check`: 2 means a file was read and broke the schema.
+const exitInvalid = 2
+
+type validateOptions struct {
+	format      string
+	printSchema string
+}
+
+// fileValidation is the outcome for one file in --format json.
+type fileValidation struct {
+	File    string         `json:"file"`
+	Valid   bool           `json:"valid"`
+	Version string         `json:"metrics_version,omitempty"`
+	Issues  []report.Issue `json:"issues,omitempty"`
+}
+
+func newValidateCmd() *cobra.Command {
+	opts := validateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate <results.json>...",
+		Short: "Check result files against the published JSON Schema",
+		Long: `Validates each results file against the JSON Schema for the metrics_version it
+declares and lists every violation with its JSON pointer. Older files are held to
+the schema of their own version, not the current one; run migrate to upgrade them.
+Exits 0 when all files are valid, 2 when any breaks the schema and 1 when a file
+can't be read.
+
+--print-schema writes the schema itself, for use in other tools.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if cmd.Flags().Changed("print-schema") {
+				printSchema(opts.printSchema)
+				return
+			}
+			if len(args) == 0 {
+				fatal(fmt.Errorf("give at least one results file, or --print-schema"))
+			}
+			runValidate(opts, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.format, "format", "f", "text", "Output format: text or json")
+	flags.StringVar(&opts.printSchema, "print-schema", models.MetricsVersion, "Print the schema for a metrics version instead of validating")
+	flags.Lookup("print-schema").NoOptDefVal = models.MetricsVersion
+
+	return cmd
+}
+
+func printSchema(version string) {
+	schema, err := report.Schema(version)
+	if err != nil {
+		fatal(err)
+	}
+	os.Stdout.Write(schema)
+}
+
+func runValidate(opts validateOptions, paths []string) {
+	if opts.format != "text" && opts.format != "json" {
+		fatal(fmt.Errorf("unknown format %q (want text or json)", opts.format))
+	}
+
+	var results []fileValidation
+	allValid := true
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fatal(fmt.Errorf("failed to read results: %w", err))
+		}
+		res := fileValidation{File: path, Valid: true}
+		if err := report.ValidateVersion(data); err != nil {
+			var verr *report.ValidationError
+			if !errors.As(err, &verr) {
+				fatal(fmt.Errorf("%s: %w", path, err))
+			}
+			res.Valid, res.Version, res.Issues = false, verr.Version, verr.Issues
+			allValid = false
+		}
+		results = append(results, res)
+	}
+
+	if opts.format == "json" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Println(string(data))
+	} else {
+		for _, res := range results {
+			if res.Valid {
+				fmt.Printf("✓ %s\n", res.File)
+				continue
+			}
+			fmt.Printf("✗ %s: %d schema violation(s)\n", res.File, len(res.Issues))
+			for _, is := range res.Issues {
+				path := is.Path
+				if path == "" {
+					path = "(root)"
+				}
+				fmt.Printf("    %s: %s\n", path, is.Message)
+			}
+		}
+	}
+
+	if !allValid {
+		os.Exit(exitInvalid)
+	}
+}
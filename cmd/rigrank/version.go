@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/telemetry"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Set at build time by goreleaser and the Makefile:
+//
+//	-ldflags "-X main.version=... -X main.commit=... -X main.date=..."
+var (
+	version = "dev"
+	commit  = ""
+	date    = ""
+)
+
+// buildInfo identifies this binary. Builds without ldflags, such as `go install`,
+// fall back to the module version and VCS stamp the Go toolchain embeds.
+func buildInfo() models.BuildInfo {
+	b := models.BuildInfo{Version: version, Commit: commit, Date: date, GoVersion: runtime.Version()}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return b
+	}
+	if b.Version == "dev" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		b.Version = info.Main.Version
+	}
+	for _, s := range info.Settings {
+		switch {
+		case s.Key == "vcs.revision" && b.Commit == "":
+			b.Commit = s.Value
+		case s.Key == "vcs.time" && b.Date == "":
+			b.Date = s.Value
+		}
+	}
+	return b
+}
+
+// commandProvenance records the build, the host and the command line a benchmarking
+// command was run with, including the effective value of every flag.
+func commandProvenance(cmd *cobra.Command) *models.Provenance {
+	options := make(map[string]string)
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		switch {
+		case f.Name == "help":
+		case isSensitive(f) && f.Changed:
+			options[f.Name] = redacted
+		default:
+			options[f.Name] = f.Value.String()
+		}
+	})
+	return &models.Provenance{
+		RigRank: buildInfo(),
+		Host:    telemetry.GetHostInfo(),
+		Command: redactArgs(os.Args, cmd.Flags()),
+		Options: options,
+	}
+}
+
+// sensitiveAnnotation marks flags whose values, such as webhook URLs and headers,
+// may hold credentials and must stay out of the recorded provenance.
+const sensitiveAnnotation = "rigrank_sensitive"
+
+const redacted = "<redacted>"
+
+func markSensitive(flags *pflag.FlagSet, names ...string) {
+	for _, name := range names {
+		flags.SetAnnotation(name, sensitiveAnnotation, []string{"true"})
+	}
+}
+
+func isSensitive(f *pflag.Flag) bool {
+	_, ok := f.Annotations[sensitiveAnnotation]
+	return ok
+}
+
+// redactArgs replaces the values of sensitive flags in a command line, whether
+// given as --flag=value or --flag value.
+func redactArgs(args []string, flags *pflag.FlagSet) []string {
+	out := slices.Clone(args)
+	for i := 0; i < len(out); i++ {
+		if out[i] == "--" {
+			break
+		}
+		name, _, hasValue := strings.Cut(strings.TrimPrefix(out[i], "--"), "=")
+		f := flags.Lookup(name)
+		if !strings.HasPrefix(out[i], "--") || f == nil || !isSensitive(f) {
+			continue
+		}
+		if hasValue {
+			out[i] = "--" + name + "=" + redacted
+		} else if i+1 < len(out) {
+			i++
+			out[i] = redacted
+		}
+	}
+	return out
+}
@@ -1,12 +1,23 @@
 package benchmark
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// DefaultOllamaURL is where every command expects the local Ollama server.
+const DefaultOllamaURL = "http://localhost:11434"
+
 // Client handles interaction with the Ollama API.
 type Client struct {
 	baseURL string
@@ -24,6 +35,12 @@ func NewClient(baseURL string) *Client {
 	}
 }
 
+// Values of GenerateResponse.DoneReason.
+const (
+	DoneReasonStop   = "stop"   // The model emitted its end-of-sequence token or a stop sequence
+	DoneReasonLength = "length" // Generation reached num_predict
+)
+
 // CheckHealth verifies Ollama is reachable.
 func CheckHealthImpl(c *Client) error {
 	resp, err := c.http.R().Head("/")
@@ -41,6 +58,7 @@ type GenerateRequest struct {
 	Model   string                 `json:"model"`
 	Prompt  string                 `json:"prompt"`
 	Stream  bool                   `json:"stream"`
+	Think   *bool                  `json:"think,omitempty"` // nil leaves the model's default
 	Options map[string]interface{} `json:"options,omitempty"`
 }
 
@@ -49,7 +67,9 @@ type GenerateResponse struct {
 	Model              string        `json:"model"`
 	CreatedAt          time.Time     `json:"created_at"`
 	Response           string        `json:"response"`
+	Thinking           string        `json:"thinking,omitempty"` // Populated when think is enabled
 	Done               bool          `json:"done"`
+	DoneReason         string        `json:"done_reason,omitempty"` // "stop" or "length", on the final chunk
 	TotalDuration      time.Duration `json:"total_duration"`
 	LoadDuration       time.Duration `json:"load_duration"`
 	PromptEvalCount    int           `json:"prompt_eval_count"`
@@ -70,10 +90,35 @@ func (c *Client) CheckHealth() error {
 	return nil
 }
 
+type versionResponse struct {
+	Version string `json:"version"`
+}
+
+// Version returns the version of the Ollama server.
+func (c *Client) Version() (string, error) {
+	var result versionResponse
+	resp, err := c.http.R().
+		SetResult(&result).
+		Get("/api/version")
+
+	if err != nil {
+		return "", err
+	}
+	if resp.IsError() {
+		return "", fmt.Errorf("version api error: %s", resp.String())
+	}
+
+	return result.Version, nil
+}
+
 // Generate sends an inference request.
-func (c *Client) Generate(req GenerateRequest) (*GenerateResponse, error) {
+func (c *Client) Generate(ctx context.Context, req GenerateRequest) (_ *GenerateResponse, err error) {
+	ctx, span := c.startGenerateSpan(ctx, req)
+	defer func() { endSpan(span, err) }()
+
 	var result GenerateResponse
 	resp, err := c.http.R().
+		SetContext(ctx).
 		SetBody(req).
 		SetResult(&result).
 		Post("/api/generate")
@@ -81,9 +126,172 @@ func (c *Client) Generate(req GenerateRequest) (*GenerateResponse, error) {
 	if err != nil {
 		return nil, err
 	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode()))
 	if resp.IsError() {
 		return nil, fmt.Errorf("generate api error: %s", resp.String())
 	}
 
 	return &result, nil
 }
+
+// startGenerateSpan opens the client span around one /api/generate request.
+func (c *Client) startGenerateSpan(ctx context.Context, req GenerateRequest) (context.Context, trace.Span) {
+	return tracer().Start(ctx, "POST /api/generate", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("http.request.method", "POST"),
+		attribute.String("url.full", c.baseURL+"/api/generate"),
+		attrModel.String(req.Model),
+		attribute.Bool("rigrank.stream", req.Stream),
+	))
+}
+
+// StreamTiming holds client-side timings observed while consuming a streamed response.
+// Each streamed chunk carries one token, so chunk counts approximate token counts.
+type StreamTiming struct {
+	FirstThinking  time.Duration // Since the request was sent; 0 if no thinking was streamed
+	FirstAnswer    time.Duration // Since the request was sent; 0 if no answer was streamed
+	LastChunk      time.Duration
+	ThinkingChunks int
+	AnswerChunks   int
+}
+
+// GenerateStream sends a streamed inference request and assembles the final response,
+// recording when the thinking and answer phases begin.
+func (c *Client) GenerateStream(ctx context.Context, req GenerateRequest) (_ *GenerateResponse, _ *StreamTiming, err error) {
+	req.Stream = true
+	ctx, span := c.startGenerateSpan(ctx, req)
+	defer func() { endSpan(span, err) }()
+	start := time.Now()
+
+	resp, err := c.http.R().
+		SetContext(ctx).
+		SetBody(req).
+		SetDoNotParseResponse(true).
+		Post("/api/generate")
+	if err != nil {
+		return nil, nil, err
+	}
+	body := resp.RawBody()
+	defer body.Close()
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode()))
+
+	if resp.IsError() {
+		msg, _ := io.ReadAll(body)
+		return nil, nil, fmt.Errorf("generate api error: %s", string(msg))
+	}
+
+	return readGenerateStream(body, start)
+}
+
+func readGenerateStream(body io.Reader, start time.Time) (*GenerateResponse, *StreamTiming, error) {
+	var result GenerateResponse
+	timing := &StreamTiming{}
+	var response, thinking []byte
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var chunk GenerateResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode stream chunk: %w", err)
+		}
+		elapsed := time.Since(start)
+		timing.LastChunk = elapsed
+
+		if chunk.Thinking != "" {
+			if timing.ThinkingChunks == 0 {
+				timing.FirstThinking = elapsed
+			}
+			timing.ThinkingChunks++
+			thinking = append(thinking, chunk.Thinking...)
+		}
+		if chunk.Response != "" {
+			if timing.AnswerChunks == 0 {
+				timing.FirstAnswer = elapsed
+			}
+			timing.AnswerChunks++
+			response = append(response, chunk.Response...)
+		}
+
+		if chunk.Done {
+			// The final chunk carries the server-side durations and counts
+			result = chunk
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	if !result.Done {
+		return nil, nil, fmt.Errorf("stream ended before completion")
+	}
+
+	result.Response = string(response)
+	result.Thinking = string(thinking)
+	return &result, timing, nil
+}
+
+// ModelDetails matches the details block of an Ollama model listing.
+type ModelDetails struct {
+	Format            string `json:"format"`
+	Family            string `json:"family"`
+	ParameterSize     string `json:"parameter_size"`
+	QuantizationLevel string `json:"quantization_level"`
+}
+
+// ModelInfo describes one locally installed model from /api/tags.
+type ModelInfo struct {
+	Name       string       `json:"name"`
+	Model      string       `json:"model"`
+	ModifiedAt time.Time    `json:"modified_at"`
+	Size       int64        `json:"size"` // Bytes on disk
+	Digest     string       `json:"digest"`
+	Details    ModelDetails `json:"details"`
+}
+
+// Metadata converts the listing into the model metadata recorded with results.
+func (m ModelInfo) Metadata() models.ModelMetadata {
+	return models.ModelMetadata{
+		Name:         m.Name,
+		Quantization: m.Details.QuantizationLevel,
+		SizeMB:       int(m.Size / (1024 * 1024)),
+		Digest:       m.Digest,
+	}
+}
+
+// FindModel returns the listed model matching name, treating a name without a tag
+// as ":latest" the way Ollama does. It returns nil when the model isn't installed.
+func FindModel(installed []ModelInfo, name string) *ModelInfo {
+	if !strings.Contains(name, ":") {
+		name += ":latest"
+	}
+	for i := range installed {
+		if installed[i].Name == name || installed[i].Model == name {
+			return &installed[i]
+		}
+	}
+	return nil
+}
+
+type tagsResponse struct {
+	Models []ModelInfo `json:"models"`
+}
+
+// ListModels returns the models installed in the local Ollama instance.
+func (c *Client) ListModels() ([]ModelInfo, error) {
+	var result tagsResponse
+	resp, err := c.http.R().
+		SetResult(&result).
+		Get("/api/tags")
+
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("tags api error: %s", resp.String())
+	}
+
+	return result.Models, nil
+}
@@ -1,7 +1,9 @@
 package benchmark
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -63,7 +65,7 @@ func TestClient_Generate(t *testing.T) {
 		},
 	}
 
-	stats, err := client.Generate(req)
+	stats, err := client.Generate(context.Background(), req)
 	if err != nil {
 		t.Fatalf("Generate() failed: %v", err)
 	}
@@ -77,3 +79,81 @@ func TestClient_Generate(t *testing.T) {
 		t.Errorf("Expected 32 prompt tokens, got %d", stats.PromptEvalCount)
 	}
 }
+
+func TestClient_GenerateStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req GenerateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if !req.Stream {
+			t.Error("Expected stream to be enabled")
+		}
+		if req.Think == nil || !*req.Think {
+			t.Error("Expected think to be enabled")
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprintln(w, `{"model":"qwen3","thinking":"Let","done":false}`)
+		fmt.Fprintln(w, `{"model":"qwen3","thinking":" me think","done":false}`)
+		fmt.Fprintln(w, `{"model":"qwen3","response":"13","done":false}`)
+		fmt.Fprintln(w, `{"model":"qwen3","response":"","done":true,"done_reason":"stop","eval_count":3,"eval_duration":30000000,"prompt_eval_count":20}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	think := true
+	resp, timing, err := client.GenerateStream(context.Background(), GenerateRequest{Model: "qwen3", Prompt: "2x+3y?", Think: &think})
+	if err != nil {
+		t.Fatalf("GenerateStream() failed: %v", err)
+	}
+
+	if resp.Response != "13" {
+		t.Errorf("Expected assembled response %q, got %q", "13", resp.Response)
+	}
+	if resp.Thinking != "Let me think" {
+		t.Errorf("Expected assembled thinking, got %q", resp.Thinking)
+	}
+	if resp.EvalCount != 3 || resp.PromptEvalCount != 20 || resp.DoneReason != DoneReasonStop {
+		t.Errorf("Expected final chunk counts and done_reason, got eval=%d prompt=%d reason=%q", resp.EvalCount, resp.PromptEvalCount, resp.DoneReason)
+	}
+	if timing.ThinkingChunks != 2 || timing.AnswerChunks != 1 {
+		t.Errorf("Expected 2 thinking and 1 answer chunk, got %d/%d", timing.ThinkingChunks, timing.AnswerChunks)
+	}
+	if timing.FirstAnswer < timing.FirstThinking {
+		t.Errorf("Expected answer to start after thinking")
+	}
+}
+
+func TestClient_ListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/tags" {
+			t.Errorf("Expected path /api/tags, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"models":[{"name":"llama3:latest","size":4661224676,"digest":"365c0bd3c000","details":{"family":"llama","parameter_size":"8.0B","quantization_level":"Q4_0"}}]}`)
+	}))
+	defer server.Close()
+
+	models, err := NewClient(server.URL).ListModels()
+	if err != nil {
+		t.Fatalf("ListModels() failed: %v", err)
+	}
+	if len(models) != 1 {
+		t.Fatalf("Expected 1 model, got %d", len(models))
+	}
+	if models[0].Details.QuantizationLevel != "Q4_0" || models[0].Size != 4661224676 {
+		t.Errorf("Unexpected model info: %+v", models[0])
+	}
+
+	found := FindModel(models, "llama3")
+	if found == nil {
+		t.Fatal("Expected an untagged name to match :latest")
+	}
+	if meta := found.Metadata(); meta.Digest != "365c0bd3c000" || meta.SizeMB != 4445 {
+		t.Errorf("Unexpected metadata: %+v", meta)
+	}
+	if FindModel(models, "llama3:70b") != nil {
+		t.Error("Expected a different tag not to match")
+	}
+}
@@ -0,0 +1,71 @@
+package benchmark
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// textSamples accumulates the generated text size alongside eval_count so the
+// tokenizer's real density can replace a fixed tokens-per-word guess.
+type textSamples struct {
+	density     models.TokenDensity
+	charsPerSec []float64
+	wordsPerSec []float64
+}
+
+func (t *textSamples) add(resp *GenerateResponse) {
+	// eval_count includes thinking tokens, so the thinking text is counted too
+	text := resp.Thinking + resp.Response
+	words := len(strings.Fields(text))
+	chars := utf8.RuneCountInString(text)
+	if resp.EvalCount == 0 || words == 0 {
+		return
+	}
+
+	t.density.Tokens += resp.EvalCount
+	t.density.Words += words
+	t.density.Chars += chars
+
+	if resp.EvalDuration > 0 {
+		t.charsPerSec = append(t.charsPerSec, float64(chars)/resp.EvalDuration.Seconds())
+		t.wordsPerSec = append(t.wordsPerSec, float64(words)/resp.EvalDuration.Seconds())
+	}
+}
+
+func (t *textSamples) result() *models.TokenDensity {
+	if t.density.Tokens == 0 {
+		return nil
+	}
+	d := t.density
+	finalizeDensity(&d)
+	return &d
+}
+
+// MeasureDensity combines the per-profile densities into one ratio for the model.
+func MeasureDensity(b *models.Benchmarks) *models.TokenDensity {
+	total := models.TokenDensity{}
+	for _, p := range []*models.ProfileStats{&b.Atomic, &b.CodeGen, &b.StoryGen, &b.Summarization, &b.Reasoning} {
+		if p.Density == nil {
+			continue
+		}
+		total.Tokens += p.Density.Tokens
+		total.Words += p.Density.Words
+		total.Chars += p.Density.Chars
+	}
+	if total.Tokens == 0 {
+		return nil
+	}
+	finalizeDensity(&total)
+	return &total
+}
+
+func finalizeDensity(d *models.TokenDensity) {
+	if d.Words > 0 {
+		d.TokensPerWord = float64(d.Tokens) / float64(d.Words)
+	}
+	if d.Chars > 0 {
+		d.TokensPerChar = float64(d.Tokens) / float64(d.Chars)
+	}
+}
@@ -0,0 +1,65 @@
+package benchmark
+
+import "github.com/rohanelukurthy/rig-rank/internal/models"
+
+// Profile names, shared by the runner, the TUI and the report card.
+const (
+	ProfileAtomic        = "Atomic Check"
+	ProfileCodeGen       = "Code Generation"
+	ProfileStoryGen      = "Story Generation"
+	ProfileSummarization = "Summarization"
+	ProfileReasoning     = "Reasoning"
+)
+
+// DefaultProfiles returns the standard suite in execution order. When think is set,
+// the Reasoning profile asks thinking models to separate reasoning from the answer.
+func DefaultProfiles(think bool) []ProfileConfig {
+	return []ProfileConfig{
+		{
+			Input: 32, Output: 16, Name: ProfileAtomic, Iterations: 5,
+			Prompt: "What is the capital of France? Answer in one word.",
+		},
+		{
+			Input: 80, Output: 256, MinOutput: 32, Name: ProfileCodeGen, Iterations: 5,
+			Prompt: "Write a Python function to find the second largest element in a list.",
+		},
+		{
+			Input: 50, Output: 400, MinOutput: 64, Name: ProfileStoryGen, Iterations: 5,
+			Prompt: "Write a short story about a robot who discovers nature.",
+		},
+		{
+			Input: 2048, Output: 128, MinOutput: 16, Name: ProfileSummarization, Iterations: 5,
+			Prompt:          generateDummyText(2048) + " Summarize the above.",
+			CheckTruncation: true,
+		},
+		{
+			// Thinking models spend most of their budget reasoning, so allow room for an answer
+			Input: 100, Output: reasoningOutput(think), MinOutput: 32, Name: ProfileReasoning, Iterations: 5,
+			Prompt: "Solve this math problem step by step: If x=2 and y=3, what is 2x + 3y?",
+			Think:  think,
+		},
+	}
+}
+
+func reasoningOutput(think bool) int {
+	if think {
+		return 1024
+	}
+	return 150
+}
+
+// StoreProfile places a profile's stats into the matching Benchmarks field.
+func StoreProfile(b *models.Benchmarks, name string, stats *models.ProfileStats) {
+	switch name {
+	case ProfileAtomic:
+		b.Atomic = *stats
+	case ProfileCodeGen:
+		b.CodeGen = *stats
+	case ProfileStoryGen:
+		b.StoryGen = *stats
+	case ProfileSummarization:
+		b.Summarization = *stats
+	case ProfileReasoning:
+		b.Reasoning = *stats
+	}
+}
@@ -1,24 +1,42 @@
 package benchmark
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sort"
+	"time"
 
 	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // BenchmarkClient Interface to allow mocking
 type BenchmarkClient interface {
-	Generate(req GenerateRequest) (*GenerateResponse, error)
+	Generate(ctx context.Context, req GenerateRequest) (*GenerateResponse, error)
+	GenerateStream(ctx context.Context, req GenerateRequest) (*GenerateResponse, *StreamTiming, error)
 	CheckHealth() error
 }
 
+// PowerMeter measures the energy drawn by the host between Start and Stop.
+type PowerMeter interface {
+	Start() error
+	Stop() (*models.EnergyStats, error)
+}
+
 // Runner executes the benchmark suite.
 type Runner struct {
 	client        BenchmarkClient
 	Debug         bool
 	ContextWindow int
+	Power         PowerMeter // Optional; when set, energy is sampled for each profile
+	Think         bool       // Enable thinking mode on the Reasoning profile
+	RawSamples    bool       // Keep every iteration's unaggregated timings in the results
+
+	windows []models.IterationWindow // Wall-clock span of every request, across profiles
+
+	ctx   context.Context // Carries the suite span between StartSuite and EndSuite
+	suite trace.Span
 }
 
 // NewRunner creates a new benchmark runner.
@@ -26,10 +44,21 @@ func NewRunner(client BenchmarkClient, contextWindow int) *Runner {
 	return &Runner{client: client, Debug: false, ContextWindow: contextWindow}
 }
 
+// Profiles returns the suite this runner executes, in order.
+func (r *Runner) Profiles() []ProfileConfig {
+	return DefaultProfiles(r.Think)
+}
+
+// IterationWindows returns the wall-clock span of every request issued so far,
+// so resource samples can be matched against the iterations they overlapped.
+func (r *Runner) IterationWindows() []models.IterationWindow {
+	return r.windows
+}
+
 // RunSuite executes all 5 test profiles.
 func (r *Runner) RunSuite(modelName string) (*models.BenchmarkResult, error) {
 	result := &models.BenchmarkResult{
-		MetricsVersion: "1.0",
+		MetricsVersion: models.MetricsVersion,
 		ModelMetadata: models.ModelMetadata{
 			Name: modelName,
 		},
@@ -38,75 +67,22 @@ func (r *Runner) RunSuite(modelName string) (*models.BenchmarkResult, error) {
 	// Collect all load durations across all iterations
 	var allLoadDurations []float64
 
-	// 1. Atomic
-	if r.Debug {
-		fmt.Println("[DEBUG] Starting Atomic Check...")
-	}
-	atomicStats, loadDurs, err := r.RunProfile(modelName, ProfileConfig{
-		Input: 32, Output: 16, Name: "Atomic Check", Iterations: 5,
-		Prompt: "What is the capital of France? Answer in one word.",
-	})
-	if err != nil {
-		return nil, fmt.Errorf("atomic profile failed: %w", err)
-	}
-	result.Benchmarks.Atomic = *atomicStats
-	allLoadDurations = append(allLoadDurations, loadDurs...)
-
-	// 2. Code Gen
-	if r.Debug {
-		fmt.Println("[DEBUG] Starting Code Generation...")
-	}
-	codeStats, loadDurs, err := r.RunProfile(modelName, ProfileConfig{
-		Input: 80, Output: 256, Name: "Code Generation", Iterations: 5,
-		Prompt: "Write a Python function to find the second largest element in a list.",
-	})
-	if err != nil {
-		return nil, err
-	}
-	result.Benchmarks.CodeGen = *codeStats
-	allLoadDurations = append(allLoadDurations, loadDurs...)
-
-	// 3. Story Gen
-	if r.Debug {
-		fmt.Println("[DEBUG] Starting Story Generation...")
-	}
-	storyStats, loadDurs, err := r.RunProfile(modelName, ProfileConfig{
-		Input: 50, Output: 400, Name: "Story Generation", Iterations: 5,
-		Prompt: "Write a short story about a robot who discovers nature.",
-	})
-	if err != nil {
-		return nil, err
-	}
-	result.Benchmarks.StoryGen = *storyStats
-	allLoadDurations = append(allLoadDurations, loadDurs...)
-
-	// 4. Summarization
-	if r.Debug {
-		fmt.Println("[DEBUG] Starting Summarization...")
-	}
-	summStats, loadDurs, err := r.RunProfile(modelName, ProfileConfig{
-		Input: 2048, Output: 128, Name: "Summarization", Iterations: 5,
-		Prompt: generateDummyText(2048) + " Summarize the above.",
-	})
-	if err != nil {
-		return nil, err
-	}
-	result.Benchmarks.Summarization = *summStats
-	allLoadDurations = append(allLoadDurations, loadDurs...)
-
-	// 5. Reasoning
-	if r.Debug {
-		fmt.Println("[DEBUG] Starting Reasoning...")
-	}
-	reasonStats, loadDurs, err := r.RunProfile(modelName, ProfileConfig{
-		Input: 100, Output: 150, Name: "Reasoning", Iterations: 5,
-		Prompt: "Solve this math problem step by step: If x=2 and y=3, what is 2x + 3y?",
-	})
-	if err != nil {
-		return nil, err
+	r.StartSuite(modelName)
+	for _, cfg := range r.Profiles() {
+		if r.Debug {
+			fmt.Printf("[DEBUG] Starting %s...\n", cfg.Name)
+		}
+		stats, loadDurs, err := r.RunProfile(modelName, cfg)
+		if err != nil {
+			err = fmt.Errorf("%s profile failed: %w", cfg.Name, err)
+			r.EndSuite(err)
+			return nil, err
+		}
+		StoreProfile(&result.Benchmarks, cfg.Name, stats)
+		allLoadDurations = append(allLoadDurations, loadDurs...)
 	}
-	result.Benchmarks.Reasoning = *reasonStats
-	allLoadDurations = append(allLoadDurations, loadDurs...)
+	r.EndSuite(nil)
+	result.TokenDensity = MeasureDensity(&result.Benchmarks)
 
 	// Analyze load durations:
 	// - InitialLoadMs: First iteration load duration (potential cold start)
@@ -132,31 +108,95 @@ type ProfileConfig struct {
 	Output     int
 	Iterations int
 	Prompt     string
+	// MinOutput is the fewest generated tokens that still give a meaningful Gen TPS. A
+	// response the model ended itself below it is flagged early_eos; 0 skips the check
+	// for prompts with intentionally short answers.
+	MinOutput int
+	// CheckTruncation compares the evaluated prompt with its estimated size. Set it on
+	// profiles whose prompts are long enough to be cut to num_ctx.
+	CheckTruncation bool
+	// Think streams the response with thinking enabled to time the reasoning and answer phases
+	Think bool
 }
 
-func (r *Runner) RunProfile(model string, cfg ProfileConfig) (*models.ProfileStats, []float64, error) {
+func (r *Runner) RunProfile(model string, cfg ProfileConfig) (_ *models.ProfileStats, _ []float64, err error) {
 	// Skip warmup here - cold start is captured at suite level
 	// Each profile just runs iterations with model already warm
+	ctx, span := tracer().Start(r.suiteContext(), "benchmark.profile", trace.WithAttributes(attrModel.String(model), attrProfile.String(cfg.Name)))
+	defer func() { endSpan(span, err) }()
 
 	var ttfts []float64
 	var genTPS []float64
 	var promptTPS []float64
 	var loadDurations []float64
+	var generatedTokens int
+	var issues [][]iterationIssue
+	var thinking thinkingSamples
+	var text textSamples
+	var raw []models.RawSample
+	var lastErr error
+	failed := 0
+
+	// Energy is sampled over the whole profile rather than per iteration:
+	// RAPL counters and nvidia-smi are too coarse for sub-second requests.
+	powerStarted := false
+	if r.Power != nil {
+		if err := r.Power.Start(); err == nil {
+			powerStarted = true
+		} else if r.Debug {
+			fmt.Printf("[DEBUG] Energy sampling unavailable: %v\n", err)
+		}
+	}
 
 	for i := 0; i < cfg.Iterations; i++ {
 		if r.Debug {
 			fmt.Printf("[DEBUG] Iteration %d/%d\n", i+1, cfg.Iterations)
 		}
-		resp, err := r.client.Generate(GenerateRequest{
+		req := GenerateRequest{
 			Model: model, Prompt: cfg.Prompt, Stream: false,
 			Options: map[string]interface{}{
 				"num_predict": cfg.Output,
 				"num_ctx":     r.ContextWindow,
 				"temperature": 0.0,
 			},
-		})
+		}
+
+		start := time.Now()
+		iterCtx, iterSpan := tracer().Start(ctx, "generate", trace.WithTimestamp(start),
+			trace.WithAttributes(attrModel.String(model), attrProfile.String(cfg.Name), attrIteration.Int(i+1)))
+		var resp *GenerateResponse
+		var err error
+		if cfg.Think {
+			think := true
+			req.Think = &think
+			var timing *StreamTiming
+			resp, timing, err = r.client.GenerateStream(iterCtx, req)
+			if err == nil {
+				thinking.add(resp, timing)
+			}
+		} else {
+			resp, err = r.client.Generate(iterCtx, req)
+		}
+		end := time.Now()
+		if err == nil {
+			iterSpan.SetAttributes(attrInputTokens.Int(resp.PromptEvalCount), attrOutputTokens.Int(resp.EvalCount))
+			tracePhases(iterCtx, start, resp)
+		}
+		endSpan(iterSpan, err, trace.WithTimestamp(end))
+		r.windows = append(r.windows, models.IterationWindow{Profile: cfg.Name, Iteration: i + 1, Start: start, End: end})
+		if r.RawSamples {
+			raw = append(raw, rawSample(i+1, start, end, resp, err))
+		}
 		if err != nil {
-			return nil, nil, err
+			// A single failed request shouldn't discard the profile; its metrics just
+			// lose a sample. Only a profile where every request failed is an error.
+			lastErr = err
+			failed++
+			issues = append(issues, []iterationIssue{{kind: WarningFailedRequest}})
+			if r.Debug {
+				fmt.Printf("[DEBUG] Iteration %d failed: %v\n", i+1, err)
+			}
+			continue
 		}
 
 		// Calculate metrics
@@ -176,20 +216,112 @@ func (r *Runner) RunProfile(model string, cfg ProfileConfig) (*models.ProfileSta
 		if resp.PromptEvalDuration > 0 {
 			promptTPS = append(promptTPS, float64(resp.PromptEvalCount)/resp.PromptEvalDuration.Seconds())
 		}
+		generatedTokens += resp.EvalCount
+		text.add(resp)
+		issues = append(issues, validateResponse(cfg, r.ContextWindow, resp))
+	}
+
+	if cfg.Iterations > 0 && failed == cfg.Iterations {
+		if powerStarted {
+			r.Power.Stop()
+		}
+		return nil, nil, lastErr
+	}
+
+	var energy *models.EnergyStats
+	if powerStarted {
+		var err error
+		energy, err = r.Power.Stop()
+		if err != nil {
+			if r.Debug {
+				fmt.Printf("[DEBUG] Energy sampling failed: %v\n", err)
+			}
+			energy = nil
+		} else {
+			applyTokenEfficiency(energy, generatedTokens)
+		}
+	}
+
+	stats := models.Stats{
+		TTFTMs:         calculateStats(ttfts),
+		GenTPS:         calculateStats(genTPS),
+		PromptTPS:      calculateStats(promptTPS),
+		LoadDurationMs: calculateStats(loadDurations),
+		CharsPerSec:    calculateStats(text.charsPerSec),
+		WordsPerSec:    calculateStats(text.wordsPerSec),
 	}
+	markUnavailable(&stats)
 
 	return &models.ProfileStats{
 		Description: cfg.Name,
-		Config:      models.Config{InputTokens: cfg.Input, OutputTokens: cfg.Output},
-		Stats: models.Stats{
-			TTFTMs:         calculateStats(ttfts),
-			GenTPS:         calculateStats(genTPS),
-			PromptTPS:      calculateStats(promptTPS),
-			LoadDurationMs: calculateStats(loadDurations),
-		},
+		Config:      models.Config{InputTokens: cfg.Input, OutputTokens: cfg.Output, Think: cfg.Think},
+		Stats:       stats,
+		Thinking:    thinking.stats(),
+		Density:     text.result(),
+		Energy:      energy,
+		Warnings:    summarizeIssues(issues),
+		RawSamples:  raw,
 	}, loadDurations, nil
 }
 
+// rawSample records one iteration as measured, before any aggregation.
+func rawSample(iteration int, start, end time.Time, resp *GenerateResponse, err error) models.RawSample {
+	s := models.RawSample{
+		Iteration:   iteration,
+		StartedAt:   start,
+		WallClockMs: durationMs(end.Sub(start)),
+	}
+	if err != nil {
+		s.Error = err.Error()
+		return s
+	}
+	s.TotalDurationMs = durationMs(resp.TotalDuration)
+	s.LoadDurationMs = durationMs(resp.LoadDuration)
+	s.PromptEvalDurationMs = durationMs(resp.PromptEvalDuration)
+	s.EvalDurationMs = durationMs(resp.EvalDuration)
+	s.PromptEvalCount = resp.PromptEvalCount
+	s.EvalCount = resp.EvalCount
+	return s
+}
+
+// durationMs converts a duration to fractional milliseconds, keeping sub-millisecond detail.
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// markUnavailable explains the metrics that ended up with no samples, so scoring and
+// rendering can show N/A with a reason instead of a number.
+func markUnavailable(s *models.Stats) {
+	if s.TTFTMs == nil {
+		s.MarkUnavailable("ttft_ms", "no request completed")
+	}
+	if s.GenTPS == nil {
+		s.MarkUnavailable("gen_tps", "eval_duration was zero in every iteration")
+	}
+	if s.PromptTPS == nil {
+		s.MarkUnavailable("prompt_tps", "prompt_eval_duration was zero in every iteration (prompt likely served from cache)")
+	}
+	if s.LoadDurationMs == nil {
+		s.MarkUnavailable("load_duration_ms", "no request completed")
+	}
+	if s.CharsPerSec == nil || s.WordsPerSec == nil {
+		s.MarkUnavailable("chars_per_sec", "no generated text with a measurable eval_duration")
+		s.MarkUnavailable("words_per_sec", "no generated text with a measurable eval_duration")
+	}
+}
+
+// applyTokenEfficiency fills the per-token energy figures. Only generated tokens are
+// counted, so efficiency reflects the decode phase users actually wait on.
+func applyTokenEfficiency(e *models.EnergyStats, generatedTokens int) {
+	e.GeneratedTokens = generatedTokens
+	if generatedTokens > 0 {
+		e.JoulesPer1kTokens = e.TotalJoules / float64(generatedTokens) * 1000
+	}
+	if e.TotalJoules > 0 {
+		e.TokensPerJoule = float64(generatedTokens) / e.TotalJoules
+	}
+}
+
 func calculateStats(values []float64) *models.StatsMetric {
 	if len(values) == 0 {
 		return nil
@@ -208,10 +340,21 @@ func calculateStats(values []float64) *models.StatsMetric {
 	}
 	p99 := values[p99Index]
 
+	var stddev float64
+	if len(values) > 1 {
+		var sq float64
+		for _, v := range values {
+			sq += (v - mean) * (v - mean)
+		}
+		stddev = math.Sqrt(sq / float64(len(values)-1))
+	}
+
 	return &models.StatsMetric{
 		Mean:   mean,
 		Median: median,
 		P99:    p99,
+		StdDev: &stddev,
+		N:      len(values),
 	}
 }
 
@@ -1,8 +1,18 @@
 package benchmark
 
 import (
+	"context"
+	"fmt"
+	"math"
 	"testing"
 	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // MockClient satisfies the interaction needed by Runner (we might need an interface later, but for now we can wrap or mock)
@@ -10,13 +20,22 @@ import (
 // Refactoring Client to Interface would be cleaner TDD.
 
 type MockBenchmarkClient struct {
-	GenerateFunc func(req GenerateRequest) (*GenerateResponse, error)
+	GenerateFunc       func(req GenerateRequest) (*GenerateResponse, error)
+	GenerateStreamFunc func(req GenerateRequest) (*GenerateResponse, *StreamTiming, error)
 }
 
-func (m *MockBenchmarkClient) Generate(req GenerateRequest) (*GenerateResponse, error) {
+func (m *MockBenchmarkClient) Generate(_ context.Context, req GenerateRequest) (*GenerateResponse, error) {
 	return m.GenerateFunc(req)
 }
 
+func (m *MockBenchmarkClient) GenerateStream(_ context.Context, req GenerateRequest) (*GenerateResponse, *StreamTiming, error) {
+	if m.GenerateStreamFunc != nil {
+		return m.GenerateStreamFunc(req)
+	}
+	resp, err := m.GenerateFunc(req)
+	return resp, &StreamTiming{}, err
+}
+
 func (m *MockBenchmarkClient) CheckHealth() error {
 	return nil
 }
@@ -63,3 +82,327 @@ func TestRunSuite(t *testing.T) {
 	}
 	// ... validation for others
 }
+
+type mockPowerMeter struct {
+	started bool
+}
+
+func (m *mockPowerMeter) Start() error {
+	m.started = true
+	return nil
+}
+
+func (m *mockPowerMeter) Stop() (*models.EnergyStats, error) {
+	return &models.EnergyStats{Sources: []string{"rapl"}, DurationMs: 1000, TotalJoules: 25, AvgWatts: 25}, nil
+}
+
+func TestRunProfile_Energy(t *testing.T) {
+	mockClient := &MockBenchmarkClient{
+		GenerateFunc: func(req GenerateRequest) (*GenerateResponse, error) {
+			return &GenerateResponse{
+				TotalDuration: 150 * time.Millisecond,
+				EvalDuration:  100 * time.Millisecond,
+				EvalCount:     100,
+			}, nil
+		},
+	}
+
+	meter := &mockPowerMeter{}
+	runner := NewRunner(mockClient, 4096)
+	runner.Power = meter
+
+	stats, _, err := runner.RunProfile("llama3", ProfileConfig{Name: "Test", Output: 100, Iterations: 5, Prompt: "hi"})
+	if err != nil {
+		t.Fatalf("RunProfile failed: %v", err)
+	}
+	if !meter.started {
+		t.Fatal("expected power meter to be started")
+	}
+	if stats.Energy == nil {
+		t.Fatal("expected energy stats")
+	}
+	if stats.Energy.GeneratedTokens != 500 {
+		t.Errorf("Expected 500 generated tokens, got %d", stats.Energy.GeneratedTokens)
+	}
+	if stats.Energy.JoulesPer1kTokens != 50 {
+		t.Errorf("Expected 50 J/1k tokens, got %v", stats.Energy.JoulesPer1kTokens)
+	}
+	if stats.Energy.TokensPerJoule != 20 {
+		t.Errorf("Expected 20 tokens/J, got %v", stats.Energy.TokensPerJoule)
+	}
+}
+
+func TestRunProfile_Thinking(t *testing.T) {
+	mockClient := &MockBenchmarkClient{
+		GenerateFunc: func(req GenerateRequest) (*GenerateResponse, error) {
+			t.Fatal("thinking profiles must stream")
+			return nil, nil
+		},
+		GenerateStreamFunc: func(req GenerateRequest) (*GenerateResponse, *StreamTiming, error) {
+			if req.Think == nil || !*req.Think {
+				t.Error("Expected think to be enabled on the request")
+			}
+			return &GenerateResponse{
+				TotalDuration: 3 * time.Second,
+				EvalDuration:  2 * time.Second,
+				EvalCount:     300,
+				Response:      "13",
+			}, &StreamTiming{
+				FirstThinking:  500 * time.Millisecond,
+				FirstAnswer:    2500 * time.Millisecond,
+				LastChunk:      3 * time.Second,
+				ThinkingChunks: 200,
+				AnswerChunks:   100,
+			}, nil
+		},
+	}
+
+	runner := NewRunner(mockClient, 4096)
+	runner.Think = true
+	profiles := runner.Profiles()
+	reasoning := profiles[len(profiles)-1]
+
+	stats, _, err := runner.RunProfile("qwen3", reasoning)
+	if err != nil {
+		t.Fatalf("RunProfile failed: %v", err)
+	}
+	if stats.Thinking == nil {
+		t.Fatal("Expected thinking stats")
+	}
+	if got := stats.Thinking.ThinkingTokens.Mean; got != 200 {
+		t.Errorf("Expected 200 thinking tokens, got %v", got)
+	}
+	if got := stats.Thinking.AnswerTokens.Mean; got != 100 {
+		t.Errorf("Expected 100 answer tokens, got %v", got)
+	}
+	if got := stats.Thinking.TimeToFirstAnswerMs.Mean; got != 2500 {
+		t.Errorf("Expected 2500ms to first answer token, got %v", got)
+	}
+	if got := stats.Thinking.ThinkingTPS.Mean; got != 100 {
+		t.Errorf("Expected 100 thinking tokens/sec, got %v", got)
+	}
+	if got := stats.Thinking.AnswerTPS.Mean; got != 200 {
+		t.Errorf("Expected 200 answer tokens/sec, got %v", got)
+	}
+}
+
+func TestRunSuite_TokenDensity(t *testing.T) {
+	mockClient := &MockBenchmarkClient{
+		GenerateFunc: func(req GenerateRequest) (*GenerateResponse, error) {
+			return &GenerateResponse{
+				TotalDuration: 2 * time.Second,
+				EvalDuration:  1 * time.Second,
+				EvalCount:     20,
+				Response:      "one two three four five six seven eight nine ten",
+			}, nil
+		},
+	}
+
+	results, err := NewRunner(mockClient, 4096).RunSuite("llama3")
+	if err != nil {
+		t.Fatalf("RunSuite failed: %v", err)
+	}
+
+	if results.TokenDensity == nil {
+		t.Fatal("Expected token density to be measured")
+	}
+	if results.TokenDensity.TokensPerWord != 2 {
+		t.Errorf("Expected 2 tokens/word, got %v", results.TokenDensity.TokensPerWord)
+	}
+	stats := results.Benchmarks.CodeGen.Stats
+	if stats.WordsPerSec == nil || stats.WordsPerSec.Mean != 10 {
+		t.Errorf("Expected 10 words/sec, got %+v", stats.WordsPerSec)
+	}
+	if stats.CharsPerSec == nil || stats.CharsPerSec.Mean != 48 {
+		t.Errorf("Expected 48 chars/sec, got %+v", stats.CharsPerSec)
+	}
+}
+
+func TestRunProfile_DegradedMeasurements(t *testing.T) {
+	calls := 0
+	mockClient := &MockBenchmarkClient{
+		GenerateFunc: func(req GenerateRequest) (*GenerateResponse, error) {
+			calls++
+			if calls == 2 {
+				return nil, fmt.Errorf("connection reset")
+			}
+			// Cached prompt and an empty generation: no eval or prompt_eval time
+			return &GenerateResponse{TotalDuration: 20 * time.Millisecond}, nil
+		},
+	}
+
+	stats, _, err := NewRunner(mockClient, 4096).RunProfile("llama3", ProfileConfig{Name: "Test", Output: 10, Iterations: 3, Prompt: "hi"})
+	if err != nil {
+		t.Fatalf("Expected a single failed request to be tolerated, got %v", err)
+	}
+	if stats.Stats.GenTPS != nil || stats.Stats.PromptTPS != nil {
+		t.Fatal("Expected gen_tps and prompt_tps to be N/A")
+	}
+	if _, ok := stats.Stats.Unavailable["prompt_tps"]; !ok {
+		t.Error("Expected a reason for the missing prompt_tps")
+	}
+
+	var failed bool
+	for _, w := range stats.Warnings {
+		if w.Kind == WarningFailedRequest && len(w.Iterations) == 1 && w.Iterations[0] == 2 {
+			failed = true
+		}
+	}
+	if !failed {
+		t.Errorf("Expected a failed_request warning for iteration 2, got %+v", stats.Warnings)
+	}
+}
+
+func TestRunProfile_AllRequestsFail(t *testing.T) {
+	mockClient := &MockBenchmarkClient{
+		GenerateFunc: func(req GenerateRequest) (*GenerateResponse, error) {
+			return nil, fmt.Errorf("model not found")
+		},
+	}
+	if _, _, err := NewRunner(mockClient, 4096).RunProfile("missing", ProfileConfig{Name: "Test", Iterations: 3}); err == nil {
+		t.Error("Expected an error when every request fails")
+	}
+}
+
+func TestCalculateStats_Spread(t *testing.T) {
+	s := calculateStats([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+	if s.N != 8 || s.Mean != 5 {
+		t.Errorf("Expected 8 samples with mean 5, got %+v", s)
+	}
+	// Sample (n-1) standard deviation of the values above
+	if s.StdDev == nil || math.Abs(*s.StdDev-2.138) > 0.001 {
+		t.Errorf("Expected stddev ~2.138, got %v", s.StdDev)
+	}
+	if one := calculateStats([]float64{3}); one.StdDev == nil || *one.StdDev != 0 || one.N != 1 {
+		t.Errorf("Expected a single sample to have no spread, got %+v", one)
+	}
+}
+
+func TestRunProfile_RawSamples(t *testing.T) {
+	calls := 0
+	mockClient := &MockBenchmarkClient{
+		GenerateFunc: func(req GenerateRequest) (*GenerateResponse, error) {
+			calls++
+			if calls == 2 {
+				return nil, fmt.Errorf("connection reset")
+			}
+			return &GenerateResponse{
+				TotalDuration:      300 * time.Millisecond,
+				LoadDuration:       1500 * time.Microsecond,
+				PromptEvalCount:    12,
+				PromptEvalDuration: 40 * time.Millisecond,
+				EvalCount:          50,
+				EvalDuration:       250 * time.Millisecond,
+			}, nil
+		},
+	}
+	cfg := ProfileConfig{Name: "Test", Output: 50, Iterations: 3, Prompt: "hi"}
+
+	stats, _, err := NewRunner(mockClient, 4096).RunProfile("llama3", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.RawSamples != nil {
+		t.Fatalf("Expected no raw samples unless asked for, got %d", len(stats.RawSamples))
+	}
+
+	calls = 0
+	runner := NewRunner(mockClient, 4096)
+	runner.RawSamples = true
+	stats, _, err = runner.RunProfile("llama3", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.RawSamples) != 3 {
+		t.Fatalf("Expected one sample per iteration including the failure, got %d", len(stats.RawSamples))
+	}
+
+	first := stats.RawSamples[0]
+	if first.Iteration != 1 || first.TotalDurationMs != 300 || first.LoadDurationMs != 1.5 ||
+		first.PromptEvalDurationMs != 40 || first.EvalDurationMs != 250 ||
+		first.PromptEvalCount != 12 || first.EvalCount != 50 || first.Error != "" {
+		t.Errorf("Unexpected first sample: %+v", first)
+	}
+	if first.StartedAt.IsZero() || first.WallClockMs < 0 {
+		t.Errorf("Expected a start time and client wall-clock, got %+v", first)
+	}
+	if failed := stats.RawSamples[1]; failed.Iteration != 2 || failed.Error == "" || failed.TotalDurationMs != 0 {
+		t.Errorf("Expected the failed iteration to carry its error and no timings, got %+v", failed)
+	}
+}
+
+func TestRunSuite_Traces(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(prev)
+
+	mockClient := &MockBenchmarkClient{
+		GenerateFunc: func(req GenerateRequest) (*GenerateResponse, error) {
+			return &GenerateResponse{
+				TotalDuration:      300 * time.Millisecond,
+				LoadDuration:       10 * time.Millisecond,
+				PromptEvalCount:    12,
+				PromptEvalDuration: 40 * time.Millisecond,
+				EvalCount:          50,
+				EvalDuration:       250 * time.Millisecond,
+			}, nil
+		},
+	}
+	runner := NewRunner(mockClient, 4096)
+	runner.StartSuite("llama3")
+	if _, _, err := runner.RunProfile("llama3", ProfileConfig{Name: "Test", Output: 50, Iterations: 2, Prompt: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+	runner.EndSuite(nil)
+
+	byID := make(map[trace.SpanID]sdktrace.ReadOnlySpan)
+	var generates []sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		byID[s.SpanContext().SpanID()] = s
+		if s.Name() == "generate" {
+			generates = append(generates, s)
+		}
+	}
+	if len(generates) != 2 {
+		t.Fatalf("Expected a generate span per iteration, got %d", len(generates))
+	}
+
+	for i, gen := range generates {
+		attrs := attribute.NewSet(gen.Attributes()...)
+		if v, _ := attrs.Value(attrProfile); v.AsString() != "Test" {
+			t.Errorf("Expected the profile attribute, got %q", v.Emit())
+		}
+		if v, _ := attrs.Value(attrIteration); v.AsInt64() != int64(i+1) {
+			t.Errorf("Expected iteration %d, got %d", i+1, v.AsInt64())
+		}
+		if v, _ := attrs.Value(attrInputTokens); v.AsInt64() != 12 {
+			t.Errorf("Expected 12 input tokens, got %d", v.AsInt64())
+		}
+		if v, _ := attrs.Value(attrOutputTokens); v.AsInt64() != 50 {
+			t.Errorf("Expected 50 output tokens, got %d", v.AsInt64())
+		}
+		profile := byID[gen.Parent().SpanID()]
+		if profile == nil || profile.Name() != "benchmark.profile" || byID[profile.Parent().SpanID()].Name() != "benchmark.suite" {
+			t.Errorf("Expected generate under benchmark.profile under benchmark.suite")
+		}
+	}
+
+	want := map[string]time.Duration{"load": 10 * time.Millisecond, "prompt_eval": 40 * time.Millisecond, "eval": 250 * time.Millisecond}
+	phases := make(map[string]time.Duration)
+	for _, s := range recorder.Ended() {
+		if s.Parent().SpanID() == generates[0].SpanContext().SpanID() {
+			phases[s.Name()] = s.EndTime().Sub(s.StartTime())
+			attrs := attribute.NewSet(s.Attributes()...)
+			synthetic, _ := attrs.Value(attrSynthetic)
+			if _, phase := want[s.Name()]; synthetic.AsBool() != phase {
+				t.Errorf("Expected only the reconstructed phases to be marked synthetic, got %s: %v", s.Name(), synthetic.AsBool())
+			}
+		}
+	}
+	for name, d := range want {
+		if phases[name] != d {
+			t.Errorf("Expected a %s child span of %v, got %v", name, d, phases[name])
+		}
+	}
+}
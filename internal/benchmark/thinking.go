@@ -0,0 +1,55 @@
+package benchmark
+
+import "github.com/rohanelukurthy/rig-rank/internal/models"
+
+// thinkingSamples accumulates per-iteration phase measurements for thinking models.
+type thinkingSamples struct {
+	thinkingTokens    []float64
+	answerTokens      []float64
+	timeToFirstAnswer []float64
+	thinkingTPS       []float64
+	answerTPS         []float64
+}
+
+func (t *thinkingSamples) add(resp *GenerateResponse, timing *StreamTiming) {
+	if timing == nil {
+		return
+	}
+
+	// eval_count covers both phases; trust it for the total and attribute the
+	// streamed thinking chunks to the reasoning phase.
+	thinkingTokens := timing.ThinkingChunks
+	answerTokens := resp.EvalCount - thinkingTokens
+	if answerTokens < 0 || resp.EvalCount == 0 {
+		answerTokens = timing.AnswerChunks
+	}
+
+	t.thinkingTokens = append(t.thinkingTokens, float64(thinkingTokens))
+	t.answerTokens = append(t.answerTokens, float64(answerTokens))
+
+	if timing.FirstAnswer > 0 {
+		t.timeToFirstAnswer = append(t.timeToFirstAnswer, float64(timing.FirstAnswer.Milliseconds()))
+	}
+
+	if thinkingTokens > 0 && timing.FirstAnswer > timing.FirstThinking {
+		phase := timing.FirstAnswer - timing.FirstThinking
+		t.thinkingTPS = append(t.thinkingTPS, float64(thinkingTokens)/phase.Seconds())
+	}
+	if answerTokens > 0 && timing.FirstAnswer > 0 && timing.LastChunk > timing.FirstAnswer {
+		phase := timing.LastChunk - timing.FirstAnswer
+		t.answerTPS = append(t.answerTPS, float64(answerTokens)/phase.Seconds())
+	}
+}
+
+func (t *thinkingSamples) stats() *models.ThinkingStats {
+	if len(t.thinkingTokens) == 0 {
+		return nil
+	}
+	return &models.ThinkingStats{
+		ThinkingTokens:      calculateStats(t.thinkingTokens),
+		AnswerTokens:        calculateStats(t.answerTokens),
+		TimeToFirstAnswerMs: calculateStats(t.timeToFirstAnswer),
+		ThinkingTPS:         calculateStats(t.thinkingTPS),
+		AnswerTPS:           calculateStats(t.answerTPS),
+	}
+}
@@ -0,0 +1,90 @@
+package benchmark
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of every span this package creates.
+const tracerName = "github.com/rohanelukurthy/rig-rank/internal/benchmark"
+
+// Span attribute keys. Token counts use the OpenTelemetry GenAI conventions so
+// trace backends recognise them.
+const (
+	attrModel        = attribute.Key("gen_ai.request.model")
+	attrInputTokens  = attribute.Key("gen_ai.usage.input_tokens")
+	attrOutputTokens = attribute.Key("gen_ai.usage.output_tokens")
+	attrProfile      = attribute.Key("rigrank.profile")
+	attrIteration    = attribute.Key("rigrank.iteration")
+	attrSynthetic    = attribute.Key("rigrank.synthetic") // Span timing reconstructed, not observed
+)
+
+// tracer is looked up on every use rather than once, so a provider installed after
+// start-up (or swapped in tests) takes effect. Without one, spans are no-ops.
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// StartSuite opens a span that the profiles run afterwards are reported under, so a
+// whole suite lands in one trace. EndSuite closes it.
+func (r *Runner) StartSuite(model string) {
+	r.ctx, r.suite = tracer().Start(context.Background(), "benchmark.suite", trace.WithAttributes(attrModel.String(model)))
+}
+
+// EndSuite closes the suite span, marking it failed when err is set.
+func (r *Runner) EndSuite(err error) {
+	if r.suite == nil {
+		return
+	}
+	endSpan(r.suite, err)
+	r.ctx, r.suite = nil, nil
+}
+
+// suiteContext is the parent of the runner's profile spans.
+func (r *Runner) suiteContext() context.Context {
+	if r.ctx != nil {
+		return r.ctx
+	}
+	return context.Background()
+}
+
+// tracePhases reports Ollama's server-side load, prompt-eval and eval durations as
+// child spans. Ollama only returns durations, so the phases are laid end to end from
+// the start of the request; network and scheduling overhead falls after them. Their
+// durations are real but their start times are not, so each is marked synthetic.
+func tracePhases(ctx context.Context, start time.Time, resp *GenerateResponse) {
+	at := start
+	for _, phase := range []struct {
+		name   string
+		d      time.Duration
+		tokens int
+	}{
+		{"load", resp.LoadDuration, 0},
+		{"prompt_eval", resp.PromptEvalDuration, resp.PromptEvalCount},
+		{"eval", resp.EvalDuration, resp.EvalCount},
+	} {
+		if phase.d <= 0 {
+			continue
+		}
+		_, span := tracer().Start(ctx, phase.name, trace.WithTimestamp(at), trace.WithAttributes(attrSynthetic.Bool(true)))
+		if phase.tokens > 0 {
+			span.SetAttributes(attribute.Int("rigrank.tokens", phase.tokens))
+		}
+		at = at.Add(phase.d)
+		span.End(trace.WithTimestamp(at))
+	}
+}
+
+// endSpan records err, if any, on the span and ends it.
+func endSpan(span trace.Span, err error, opts ...trace.SpanEndOption) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End(opts...)
+}
@@ -0,0 +1,124 @@
+package benchmark
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+const (
+	WarningEarlyEOS          = "early_eos"
+	WarningContextTruncation = "context_truncation"
+	WarningDegenerateOutput  = "degenerate_output"
+	WarningFailedRequest     = "failed_request"
+)
+
+const (
+	// prompt_eval_count below this fraction of the estimated prompt size means truncation
+	truncationRatio = 0.5
+	// Output loops when fewer than this fraction of its n-grams are distinct
+	distinctNGramRatio = 0.5
+	repeatNGramSize    = 4
+	minWordsForRepeat  = 24
+)
+
+// iterationIssue is a single validity problem found in one response.
+type iterationIssue struct {
+	kind string
+	// observed and expected describe the size of the problem for the aggregated message
+	observed int
+	expected int
+}
+
+// validateResponse checks a response for signs that its timings don't describe the
+// workload the profile intended to measure.
+func validateResponse(cfg ProfileConfig, contextWindow int, resp *GenerateResponse) []iterationIssue {
+	var issues []iterationIssue
+
+	// A concise answer is not a problem in itself: only a response the model ended on
+	// its own, too short to time generation by, is. Reaching num_predict never is.
+	if cfg.MinOutput > 0 && resp.DoneReason != DoneReasonLength && resp.EvalCount < cfg.MinOutput {
+		issues = append(issues, iterationIssue{kind: WarningEarlyEOS, observed: resp.EvalCount, expected: cfg.MinOutput})
+	}
+
+	expectedPrompt := estimateTokens(cfg.Prompt)
+	if cfg.CheckTruncation || (contextWindow > 0 && expectedPrompt > contextWindow) {
+		if float64(resp.PromptEvalCount) < float64(expectedPrompt)*truncationRatio {
+			issues = append(issues, iterationIssue{kind: WarningContextTruncation, observed: resp.PromptEvalCount, expected: expectedPrompt})
+		}
+	}
+
+	if isDegenerate(resp.Response) {
+		issues = append(issues, iterationIssue{kind: WarningDegenerateOutput})
+	}
+
+	return issues
+}
+
+// summarizeIssues folds per-iteration issues into one warning per kind.
+func summarizeIssues(perIteration [][]iterationIssue) []models.RunWarning {
+	type agg struct {
+		iterations []int
+		observed   int
+		expected   int
+	}
+	byKind := make(map[string]*agg)
+	var order []string
+
+	for i, issues := range perIteration {
+		for _, issue := range issues {
+			a, ok := byKind[issue.kind]
+			if !ok {
+				a = &agg{}
+				byKind[issue.kind] = a
+				order = append(order, issue.kind)
+			}
+			a.iterations = append(a.iterations, i+1)
+			a.observed += issue.observed
+			a.expected = issue.expected
+		}
+	}
+
+	var warnings []models.RunWarning
+	for _, kind := range order {
+		a := byKind[kind]
+		n := len(a.iterations)
+		var msg string
+		switch kind {
+		case WarningEarlyEOS:
+			msg = fmt.Sprintf("Generation stopped early in %d/%d iterations (avg %d tokens, fewer than the %d needed); Gen TPS is unreliable.",
+				n, len(perIteration), a.observed/n, a.expected)
+		case WarningContextTruncation:
+			msg = fmt.Sprintf("Only ~%d of ~%d prompt tokens were evaluated in %d/%d iterations (truncated to num_ctx or served from cache); Prompt TPS is unreliable.",
+				a.observed/n, a.expected, n, len(perIteration))
+		case WarningDegenerateOutput:
+			msg = fmt.Sprintf("Output looped on repeated phrases in %d/%d iterations.", n, len(perIteration))
+		case WarningFailedRequest:
+			msg = fmt.Sprintf("%d/%d requests failed; metrics use the remaining iterations.", n, len(perIteration))
+		}
+		warnings = append(warnings, models.RunWarning{Kind: kind, Message: msg, Iterations: a.iterations})
+	}
+	return warnings
+}
+
+// estimateTokens approximates the token count of a prompt at ~4 characters per token,
+// the same ratio generateDummyText uses to size the summarization input.
+func estimateTokens(prompt string) int {
+	return len(prompt) / 4
+}
+
+// isDegenerate reports whether text mostly repeats the same word n-grams.
+func isDegenerate(text string) bool {
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) < minWordsForRepeat {
+		return false
+	}
+
+	total := len(words) - repeatNGramSize + 1
+	distinct := make(map[string]struct{}, total)
+	for i := 0; i < total; i++ {
+		distinct[strings.Join(words[i:i+repeatNGramSize], " ")] = struct{}{}
+	}
+	return float64(len(distinct)) < float64(total)*distinctNGramRatio
+}
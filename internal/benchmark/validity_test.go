@@ -0,0 +1,123 @@
+package benchmark
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateResponse(t *testing.T) {
+	longPrompt := generateDummyText(2048)
+
+	tests := []struct {
+		name  string
+		cfg   ProfileConfig
+		resp  GenerateResponse
+		kinds []string
+	}{
+		{
+			name:  "healthy run",
+			cfg:   ProfileConfig{Output: 100, Prompt: longPrompt},
+			resp:  GenerateResponse{EvalCount: 100, PromptEvalCount: 2048, Response: "A perfectly normal answer."},
+			kinds: nil,
+		},
+		{
+			name:  "early eos",
+			cfg:   ProfileConfig{Output: 256, MinOutput: 32, Prompt: "Write code."},
+			resp:  GenerateResponse{EvalCount: 5, DoneReason: DoneReasonStop},
+			kinds: []string{WarningEarlyEOS},
+		},
+		{
+			name:  "concise answer at the threshold",
+			cfg:   ProfileConfig{Output: 256, MinOutput: 32, Prompt: "Write code."},
+			resp:  GenerateResponse{EvalCount: 32, DoneReason: DoneReasonStop},
+			kinds: nil,
+		},
+		{
+			name:  "one token short of the threshold",
+			cfg:   ProfileConfig{Output: 256, MinOutput: 32, Prompt: "Write code."},
+			resp:  GenerateResponse{EvalCount: 31, DoneReason: DoneReasonStop},
+			kinds: []string{WarningEarlyEOS},
+		},
+		{
+			name:  "reached num_predict",
+			cfg:   ProfileConfig{Output: 16, MinOutput: 32, Prompt: "Write code."},
+			resp:  GenerateResponse{EvalCount: 16, DoneReason: DoneReasonLength},
+			kinds: nil,
+		},
+		{
+			name:  "no done_reason from older servers",
+			cfg:   ProfileConfig{Output: 256, MinOutput: 32, Prompt: "Write code."},
+			resp:  GenerateResponse{EvalCount: 5},
+			kinds: []string{WarningEarlyEOS},
+		},
+		{
+			name:  "early eos allowed",
+			cfg:   ProfileConfig{Output: 16, Prompt: "Capital of France?"},
+			resp:  GenerateResponse{EvalCount: 2, DoneReason: DoneReasonStop},
+			kinds: nil,
+		},
+		{
+			name:  "truncated prompt",
+			cfg:   ProfileConfig{Output: 10, Prompt: longPrompt, CheckTruncation: true},
+			resp:  GenerateResponse{EvalCount: 10, PromptEvalCount: 512},
+			kinds: []string{WarningContextTruncation},
+		},
+		{
+			name:  "prompt just above the truncation ratio",
+			cfg:   ProfileConfig{Output: 10, Prompt: longPrompt, CheckTruncation: true},
+			resp:  GenerateResponse{EvalCount: 10, PromptEvalCount: estimateTokens(longPrompt) / 2},
+			kinds: nil,
+		},
+		{
+			name:  "short prompt tokenized densely",
+			cfg:   ProfileConfig{Output: 10, Prompt: strings.Repeat("x", 400)},
+			resp:  GenerateResponse{EvalCount: 10, PromptEvalCount: 20},
+			kinds: nil,
+		},
+		{
+			name:  "prompt larger than the context window",
+			cfg:   ProfileConfig{Output: 10, Prompt: strings.Repeat(longPrompt, 3)},
+			resp:  GenerateResponse{EvalCount: 10, PromptEvalCount: 2000},
+			kinds: []string{WarningContextTruncation},
+		},
+		{
+			name:  "looping output",
+			cfg:   ProfileConfig{Output: 10, Prompt: "Tell a story."},
+			resp:  GenerateResponse{EvalCount: 10, Response: strings.Repeat("the robot saw a tree ", 20)},
+			kinds: []string{WarningDegenerateOutput},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := validateResponse(tt.cfg, 4096, &tt.resp)
+			if len(issues) != len(tt.kinds) {
+				t.Fatalf("Expected %v, got %+v", tt.kinds, issues)
+			}
+			for i, kind := range tt.kinds {
+				if issues[i].kind != kind {
+					t.Errorf("Expected %s, got %s", kind, issues[i].kind)
+				}
+			}
+		})
+	}
+}
+
+func TestSummarizeIssues(t *testing.T) {
+	perIteration := [][]iterationIssue{
+		{{kind: WarningEarlyEOS, observed: 4, expected: 256}},
+		nil,
+		{{kind: WarningEarlyEOS, observed: 6, expected: 256}},
+	}
+
+	warnings := summarizeIssues(perIteration)
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d", len(warnings))
+	}
+	if got := warnings[0].Iterations; len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("Expected iterations [1 3], got %v", got)
+	}
+	if !strings.Contains(warnings[0].Message, "2/3") {
+		t.Errorf("Expected message to mention 2/3 iterations, got %q", warnings[0].Message)
+	}
+}
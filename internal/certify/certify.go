@@ -0,0 +1,125 @@
+package certify
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/history"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// Report is the qualification outcome for one machine against one policy.
+type Report struct {
+	Policy        string              `json:"policy"`
+	PolicyVersion string              `json:"policy_version,omitempty"`
+	PolicyDigest  string              `json:"policy_digest"`
+	Timestamp     time.Time           `json:"timestamp"`
+	SystemInfo    *models.SystemInfo  `json:"system_info"`
+	Fingerprint   string              `json:"hardware_fingerprint"`
+	Qualified     bool                `json:"qualified"`
+	Requirements  []RequirementResult `json:"requirements"`
+	Models        []ModelRun          `json:"models"`
+	SignOff       *SignOff            `json:"sign_off,omitempty"`
+
+	raw []byte // The file LoadReport read, verified as written
+}
+
+// RequirementResult is the pass/fail decision for one requirement.
+type RequirementResult struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	Model       string `json:"model"`
+	Expected    string `json:"expected"`
+	Actual      string `json:"actual"`
+	Passed      bool   `json:"passed"`
+}
+
+// ModelRun summarises the benchmark a requirement was judged on.
+type ModelRun struct {
+	Name   string  `json:"name"`
+	Digest string  `json:"digest,omitempty"`
+	Score  float64 `json:"rigrank_score,omitempty"`
+	Error  string  `json:"error,omitempty"` // Set when the model couldn't be benchmarked
+}
+
+// ErrOtherHardware is returned for results measured on a machine other than the one
+// being certified, which can't speak for its hardware.
+var ErrOtherHardware = errors.New("results were measured on different hardware")
+
+// CheckHardware verifies that a results file was measured on hardware with the same
+// fingerprint as sys.
+func CheckHardware(sys *models.SystemInfo, r *models.FullReport) error {
+	if r.SystemInfo == nil {
+		return fmt.Errorf("%w: the results record no hardware", ErrOtherHardware)
+	}
+	if got, want := history.Fingerprint(r.SystemInfo), history.Fingerprint(sys); got != want {
+		return fmt.Errorf("%w: fingerprint %s, this machine is %s", ErrOtherHardware, got, want)
+	}
+	return nil
+}
+
+// Evaluate judges every requirement against the benchmarked results, keyed by model
+// name. A model with no results fails all of its requirements.
+func Evaluate(p *Policy, sys *models.SystemInfo, results map[string]*models.FullReport, runErrors map[string]error, at time.Time) *Report {
+	report := &Report{
+		Policy:        p.Name,
+		PolicyVersion: p.Version,
+		PolicyDigest:  p.Digest(),
+		Timestamp:     at.UTC(),
+		SystemInfo:    sys,
+		Fingerprint:   history.Fingerprint(sys),
+		Qualified:     true,
+	}
+
+	for _, name := range p.Models() {
+		run := ModelRun{Name: name}
+		if r := results[name]; r != nil {
+			run.Digest = r.InferenceResults.ModelMetadata.Digest
+			if u := r.UseCaseSuitability; u != nil && u.Score != nil {
+				run.Score = u.Score.Composite
+			}
+		} else if err := runErrors[name]; err != nil {
+			run.Error = err.Error()
+		} else {
+			run.Error = "not benchmarked"
+		}
+		report.Models = append(report.Models, run)
+	}
+
+	for i := range p.Requirements {
+		req := &p.Requirements[i]
+		res := RequirementResult{ID: req.ID, Description: req.Description, Model: req.Model, Expected: req.expected()}
+		if r := results[req.Model]; r != nil {
+			res.Actual, res.Passed = req.check(r)
+		} else {
+			res.Actual = "no results"
+		}
+		if !res.Passed {
+			report.Qualified = false
+		}
+		report.Requirements = append(report.Requirements, res)
+	}
+	return report
+}
+
+// check returns the measured value as text and whether it meets the requirement.
+func (r *Requirement) check(full *models.FullReport) (string, bool) {
+	if r.UseCase != "" {
+		if full.UseCaseSuitability == nil {
+			return "not rated", false
+		}
+		rating := full.UseCaseSuitability.UseCase(r.UseCase).Rating
+		got := slices.Index(ratingOrder, rating)
+		return rating, got >= 0 && got <= slices.Index(ratingOrder, r.MinRating)
+	}
+
+	stats := &full.InferenceResults.Benchmarks.Profile(r.Profile).Stats
+	value, ok := stats.Metric(r.Metric).Statistic(r.Statistic)
+	if !ok {
+		return fmt.Sprintf("N/A (%s)", stats.UnavailableReason(r.Metric)), false
+	}
+	passed := (r.Min == nil || value >= *r.Min) && (r.Max == nil || value <= *r.Max)
+	return fmt.Sprintf("%.1f", value), passed
+}
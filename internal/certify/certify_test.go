@@ -0,0 +1,188 @@
+package certify
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/scoring"
+)
+
+const testPolicy = `{
+	"name": "eng-laptop",
+	"version": "2026.1",
+	"requirements": [
+		{"id": "chat", "model": "llama3", "use_case": "quick_qa", "min_rating": "GOOD"},
+		{"id": "code", "model": "llama3", "profile": "code_gen", "metric": "gen_tps", "min": 30},
+		{"id": "big", "model": "llama3:70b", "use_case": "coding", "min_rating": "MARGINAL"}
+	]
+}`
+
+func llama3Results(ttft, codeTPS float64) *models.FullReport {
+	r := &models.BenchmarkResult{ModelMetadata: models.ModelMetadata{Name: "llama3", Digest: "abc"}}
+	r.Benchmarks.Atomic.Stats.TTFTMs = &models.StatsMetric{Mean: ttft}
+	r.Benchmarks.CodeGen.Stats.GenTPS = &models.StatsMetric{Mean: codeTPS}
+	return &models.FullReport{InferenceResults: r, UseCaseSuitability: scoring.Evaluate(r)}
+}
+
+func TestEvaluate(t *testing.T) {
+	p, err := ParsePolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("ParsePolicy failed: %v", err)
+	}
+	if got := p.Models(); len(got) != 2 || got[0] != "llama3" || got[1] != "llama3:70b" {
+		t.Errorf("Unexpected models %v", got)
+	}
+
+	results := map[string]*models.FullReport{"llama3": llama3Results(120, 35)}
+	runErrors := map[string]error{"llama3:70b": errors.New("model not found")}
+	report := Evaluate(p, &models.SystemInfo{}, results, runErrors, time.Now())
+
+	want := map[string]bool{"chat": true, "code": true, "big": false}
+	for _, r := range report.Requirements {
+		if r.Passed != want[r.ID] {
+			t.Errorf("%s: expected passed=%v, got %+v", r.ID, want[r.ID], r)
+		}
+	}
+	if report.Qualified {
+		t.Error("Expected a failed requirement to disqualify the machine")
+	}
+	if report.Models[1].Error != "model not found" || report.PolicyDigest == "" {
+		t.Errorf("Expected run errors and the policy digest in the report, got %+v", report)
+	}
+
+	// A slower run misses the rating floor
+	report = Evaluate(p, nil, map[string]*models.FullReport{"llama3": llama3Results(600, 35)}, nil, time.Now())
+	if report.Requirements[0].Passed || report.Requirements[0].Actual != scoring.RatingPoor {
+		t.Errorf("Expected POOR quick_qa to fail GOOD minimum, got %+v", report.Requirements[0])
+	}
+}
+
+func TestCheckHardware(t *testing.T) {
+	sys := &models.SystemInfo{Arch: "arm64", CPU: models.CPU{Model: "Apple M3"}}
+	r := llama3Results(120, 35)
+
+	if err := CheckHardware(sys, r); !errors.Is(err, ErrOtherHardware) {
+		t.Errorf("Expected results without hardware to be rejected, got %v", err)
+	}
+	r.SystemInfo = &models.SystemInfo{Arch: "amd64", CPU: models.CPU{Model: "Ryzen 7"}}
+	if err := CheckHardware(sys, r); !errors.Is(err, ErrOtherHardware) {
+		t.Errorf("Expected results from other hardware to be rejected, got %v", err)
+	}
+	r.SystemInfo = &models.SystemInfo{Arch: "arm64", CPU: models.CPU{Model: "Apple M3"}}
+	if err := CheckHardware(sys, r); err != nil {
+		t.Errorf("Expected results from this machine to be accepted, got %v", err)
+	}
+}
+
+func TestParsePolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name, json, want string
+	}{
+		{"empty", `{"requirements": []}`, "no requirements"},
+		{"no model", `{"requirements": [{"use_case": "coding", "min_rating": "GOOD"}]}`, "model is required"},
+		{"both kinds", `{"requirements": [{"model": "m", "use_case": "coding", "min_rating": "GOOD", "profile": "atomic"}]}`, "not both"},
+		{"bad rating", `{"requirements": [{"model": "m", "use_case": "coding", "min_rating": "GREAT"}]}`, "unknown min_rating"},
+		{"no bound", `{"requirements": [{"model": "m", "profile": "atomic", "metric": "ttft_ms"}]}`, "set min, max"},
+	}
+	for _, tt := range tests {
+		_, err := ParsePolicy([]byte(tt.json))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	dir := t.TempDir()
+	privPath, pubPath := filepath.Join(dir, "key.pem"), filepath.Join(dir, "key.pub.pem")
+	if err := GenerateKey(privPath, pubPath); err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	priv, err := LoadPrivateKey(privPath)
+	if err != nil {
+		t.Fatalf("LoadPrivateKey failed: %v", err)
+	}
+	pub, err := LoadPublicKey(pubPath)
+	if err != nil {
+		t.Fatalf("LoadPublicKey failed: %v", err)
+	}
+
+	p, _ := ParsePolicy([]byte(testPolicy))
+	report := Evaluate(p, &models.SystemInfo{Arch: "arm64"}, map[string]*models.FullReport{"llama3": llama3Results(120, 35)}, nil, time.Now())
+	if err := Sign(report, "IT Procurement", priv, time.Now()); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	// The signature must survive a round trip through the JSON file
+	data, _ := json.Marshal(report)
+	var loaded Report
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(&loaded, pub); err != nil {
+		t.Errorf("Expected a valid signature, got %v", err)
+	}
+
+	loaded.Qualified = !loaded.Qualified
+	if err := Verify(&loaded, nil); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Expected tampering to be detected, got %v", err)
+	}
+
+	_, other, _ := ed25519.GenerateKey(nil)
+	loaded.Qualified = !loaded.Qualified
+	if err := Verify(&loaded, other.Public().(ed25519.PublicKey)); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Expected a different trusted key to be rejected, got %v", err)
+	}
+
+	// Who signed and when are sealed along with the report
+	loaded.SignOff.SignedBy = "Someone Else"
+	if err := Verify(&loaded, pub); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Expected a changed signed_by to be detected, got %v", err)
+	}
+	loaded.SignOff.SignedBy = "IT Procurement"
+	loaded.SignOff.SignedAt = loaded.SignOff.SignedAt.Add(-24 * time.Hour)
+	if err := Verify(&loaded, pub); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Expected a changed signed_at to be detected, got %v", err)
+	}
+}
+
+func TestVerify_FileBytes(t *testing.T) {
+	p, _ := ParsePolicy([]byte(testPolicy))
+	report := Evaluate(p, &models.SystemInfo{Arch: "arm64"}, map[string]*models.FullReport{"llama3": llama3Results(120, 35)}, nil, time.Now())
+	if err := Sign(report, "", nil, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.MarshalIndent(report, "", "  ")
+
+	path := filepath.Join(t.TempDir(), "qualification.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(loaded, nil); err != nil {
+		t.Fatalf("Expected the file as written to verify, got %v", err)
+	}
+
+	// A field the Report struct doesn't know would vanish in a re-marshal
+	edited := strings.Replace(string(data), `"qualified":`, `"waiver": "approved by phone",
+  "qualified":`, 1)
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, err = LoadReport(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(loaded, nil); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("Expected an added field to be detected, got %v", err)
+	}
+}
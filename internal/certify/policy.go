@@ -0,0 +1,157 @@
+package certify
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/scoring"
+)
+
+// ratingOrder ranks ratings from best to worst. INSUFFICIENT_DATA never meets a minimum.
+var ratingOrder = []string{scoring.RatingExcellent, scoring.RatingGood, scoring.RatingMarginal, scoring.RatingPoor}
+
+// Policy lists the requirements a machine must meet to qualify.
+type Policy struct {
+	Name         string        `json:"name"`
+	Version      string        `json:"version"`
+	Requirements []Requirement `json:"requirements"`
+
+	digest string // SHA-256 of the policy file, recorded in reports
+}
+
+// Requirement is either a minimum rating for a use case, or a floor / ceiling on one
+// statistic of one profile's metric, for a single model.
+type Requirement struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+	Model       string `json:"model"`
+
+	// Rating requirement
+	UseCase   string `json:"use_case,omitempty"`
+	MinRating string `json:"min_rating,omitempty"`
+
+	// Metric requirement
+	Profile   string   `json:"profile,omitempty"`
+	Metric    string   `json:"metric,omitempty"`
+	Statistic string   `json:"statistic,omitempty"` // Defaults to mean
+	Min       *float64 `json:"min,omitempty"`
+	Max       *float64 `json:"max,omitempty"`
+}
+
+// LoadPolicy reads and validates a qualification policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read qualification policy: %w", err)
+	}
+	p, err := ParsePolicy(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// ParsePolicy decodes and validates a qualification policy.
+func ParsePolicy(data []byte) (*Policy, error) {
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid qualification policy: %w", err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	p.digest = hex.EncodeToString(sum[:])
+	return &p, nil
+}
+
+// Digest is the SHA-256 of the policy file, so a report names the exact bar it was held to.
+func (p *Policy) Digest() string {
+	return p.digest
+}
+
+// Models lists the distinct models the policy needs benchmarked, in first-use order.
+func (p *Policy) Models() []string {
+	var names []string
+	for _, r := range p.Requirements {
+		if !slices.Contains(names, r.Model) {
+			names = append(names, r.Model)
+		}
+	}
+	return names
+}
+
+func (p *Policy) validate() error {
+	if len(p.Requirements) == 0 {
+		return fmt.Errorf("qualification policy has no requirements")
+	}
+	seen := make(map[string]bool)
+	for i := range p.Requirements {
+		r := &p.Requirements[i]
+		if r.ID == "" {
+			r.ID = fmt.Sprintf("req-%d", i+1)
+		}
+		where := fmt.Sprintf("requirement %d (%s)", i+1, r.ID)
+		if seen[r.ID] {
+			return fmt.Errorf("%s: duplicate id", where)
+		}
+		seen[r.ID] = true
+		if r.Model == "" {
+			return fmt.Errorf("%s: model is required", where)
+		}
+
+		isRating := r.UseCase != "" || r.MinRating != ""
+		isMetric := r.Profile != "" || r.Metric != "" || r.Min != nil || r.Max != nil
+		switch {
+		case isRating && isMetric:
+			return fmt.Errorf("%s: set either use_case/min_rating or profile/metric/min/max, not both", where)
+		case isRating:
+			if !slices.Contains(models.UseCaseKeys, r.UseCase) {
+				return fmt.Errorf("%s: unknown use_case %q (want one of %s)", where, r.UseCase, strings.Join(models.UseCaseKeys, ", "))
+			}
+			if !slices.Contains(ratingOrder, r.MinRating) {
+				return fmt.Errorf("%s: unknown min_rating %q (want one of %s)", where, r.MinRating, strings.Join(ratingOrder, ", "))
+			}
+		case isMetric:
+			if r.Statistic == "" {
+				r.Statistic = "mean"
+			}
+			if !slices.Contains(models.ProfileKeys, r.Profile) {
+				return fmt.Errorf("%s: unknown profile %q (want one of %s)", where, r.Profile, strings.Join(models.ProfileKeys, ", "))
+			}
+			if !slices.Contains(models.MetricKeys, r.Metric) {
+				return fmt.Errorf("%s: unknown metric %q (want one of %s)", where, r.Metric, strings.Join(models.MetricKeys, ", "))
+			}
+			if !slices.Contains(models.StatisticKeys, r.Statistic) {
+				return fmt.Errorf("%s: unknown statistic %q (want one of %s)", where, r.Statistic, strings.Join(models.StatisticKeys, ", "))
+			}
+			if r.Min == nil && r.Max == nil {
+				return fmt.Errorf("%s: set min, max or both", where)
+			}
+		default:
+			return fmt.Errorf("%s: needs a use_case/min_rating or a profile/metric bound", where)
+		}
+	}
+	return nil
+}
+
+// expected describes the requirement's bar for reports.
+func (r *Requirement) expected() string {
+	if r.UseCase != "" {
+		return fmt.Sprintf("%s rated %s or better", r.UseCase, r.MinRating)
+	}
+	var bounds []string
+	if r.Min != nil {
+		bounds = append(bounds, fmt.Sprintf(">= %g", *r.Min))
+	}
+	if r.Max != nil {
+		bounds = append(bounds, fmt.Sprintf("<= %g", *r.Max))
+	}
+	return fmt.Sprintf("%s.%s %s %s", r.Profile, r.Metric, r.Statistic, strings.Join(bounds, " and "))
+}
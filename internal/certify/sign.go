@@ -0,0 +1,238 @@
+package certify
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	// AlgorithmDigest only catches accidental edits: anyone can recompute the digest
+	// after changing the report, so it is not tamper-proof
+	AlgorithmDigest = "sha256"
+	// AlgorithmEd25519 also proves who signed it, provided the verifier checks the
+	// signature against a public key it got from the signer rather than the embedded one
+	AlgorithmEd25519 = "ed25519"
+)
+
+// ErrSignatureInvalid is returned when a report was changed after it was signed off.
+var ErrSignatureInvalid = errors.New("qualification report signature is invalid")
+
+// SignOff records who approved the report and seals its contents.
+type SignOff struct {
+	SignedBy  string    `json:"signed_by,omitempty"`
+	SignedAt  time.Time `json:"signed_at"`
+	Algorithm string    `json:"algorithm"`
+	Content   string    `json:"content"`              // Base64 canonical JSON of the report and the sign-off's other fields
+	Digest    string    `json:"digest"`               // SHA-256 of the canonical content
+	PublicKey string    `json:"public_key,omitempty"` // Base64 Ed25519 public key
+	Signature string    `json:"signature,omitempty"`  // Base64 Ed25519 signature of the digest
+}
+
+// Sign seals the report together with who signed it and when. With a nil key only
+// the digest is recorded.
+func Sign(r *Report, signedBy string, key ed25519.PrivateKey, at time.Time) error {
+	so := &SignOff{
+		SignedBy:  signedBy,
+		SignedAt:  at.UTC(),
+		Algorithm: AlgorithmDigest,
+	}
+	if key != nil {
+		so.Algorithm = AlgorithmEd25519
+		so.PublicKey = base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+	}
+	r.SignOff = so
+	content, err := canonicalContent(r)
+	if err != nil {
+		r.SignOff = nil
+		return err
+	}
+	sum := sha256.Sum256(content)
+	digest := sum[:]
+	so.Content = base64.StdEncoding.EncodeToString(content)
+	so.Digest = hex.EncodeToString(digest)
+	if key != nil {
+		so.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, digest))
+	}
+	return nil
+}
+
+// Verify checks that the report matches its sign-off. When trusted is set, the report
+// must also have been signed by that key rather than whichever key it embeds.
+func Verify(r *Report, trusted ed25519.PublicKey) error {
+	so := r.SignOff
+	if so == nil {
+		return fmt.Errorf("%w: report is not signed off", ErrSignatureInvalid)
+	}
+	signed, err := base64.StdEncoding.DecodeString(so.Content)
+	if err != nil || len(signed) == 0 {
+		return fmt.Errorf("%w: sign-off has no signed content", ErrSignatureInvalid)
+	}
+	sum := sha256.Sum256(signed)
+	digest := sum[:]
+	if hex.EncodeToString(digest) != so.Digest {
+		return fmt.Errorf("%w: signed content does not match the digest", ErrSignatureInvalid)
+	}
+	content, err := canonicalContent(r)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(content, signed) {
+		return fmt.Errorf("%w: report was changed after it was signed off", ErrSignatureInvalid)
+	}
+
+	switch so.Algorithm {
+	case AlgorithmDigest:
+		if trusted != nil {
+			return fmt.Errorf("%w: report has no signature to check against the public key", ErrSignatureInvalid)
+		}
+		return nil
+	case AlgorithmEd25519:
+		pub, err := base64.StdEncoding.DecodeString(so.PublicKey)
+		if err != nil || len(pub) != ed25519.PublicKeySize {
+			return fmt.Errorf("%w: malformed public key", ErrSignatureInvalid)
+		}
+		if trusted != nil && !trusted.Equal(ed25519.PublicKey(pub)) {
+			return fmt.Errorf("%w: signed by a different key", ErrSignatureInvalid)
+		}
+		sig, err := base64.StdEncoding.DecodeString(so.Signature)
+		if err != nil || !ed25519.Verify(pub, digest, sig) {
+			return fmt.Errorf("%w: signature does not match", ErrSignatureInvalid)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unknown algorithm %q", ErrSignatureInvalid, so.Algorithm)
+}
+
+// canonicalContent is the byte form that is signed: the report's JSON with object
+// keys sorted, no whitespace and numbers exactly as written. The sign-off is kept
+// minus the fields that are derived from the signed bytes (content, digest and
+// signature), so the signer, time, algorithm and key are sealed with the report. A
+// report read from a file is canonicalized from the file's own bytes, so fields this
+// version doesn't know about are covered too.
+func canonicalContent(r *Report) ([]byte, error) {
+	data := r.raw
+	if data == nil {
+		var err error
+		if data, err = json.Marshal(r); err != nil {
+			return nil, err
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if so, ok := doc["sign_off"].(map[string]any); ok {
+		for _, field := range unsignedFields {
+			delete(so, field)
+		}
+	}
+
+	// encoding/json sorts map keys and writes json.Number verbatim
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// unsignedFields are the sign-off fields computed from the canonical content, which
+// therefore can't be part of it.
+var unsignedFields = []string{"content", "digest", "signature"}
+
+// GenerateKey writes a new Ed25519 key pair as PEM files.
+func GenerateKey(privatePath, publicPath string) error {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return err
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600); err != nil {
+		return fmt.Errorf("failed to write private key: %w", err)
+	}
+	if err := os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0644); err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+	return nil
+}
+
+// LoadPrivateKey reads a PEM-encoded PKCS#8 Ed25519 private key.
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an Ed25519 private key", path)
+	}
+	return priv, nil
+}
+
+// LoadPublicKey reads a PEM-encoded PKIX Ed25519 public key.
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an Ed25519 public key", path)
+	}
+	return pub, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", path)
+	}
+	return block, nil
+}
+
+// LoadReport reads a qualification report written by `rigrank certify`.
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read qualification report: %w", err)
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("%s: invalid qualification report: %w", path, err)
+	}
+	r.raw = data
+	return &r, nil
+}
@@ -0,0 +1,148 @@
+package compare
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// DefaultCheckMetrics are gated unless told otherwise. Load time depends on whether
+// the model was already resident, and chars/words per second track gen_tps.
+var DefaultCheckMetrics = []string{"ttft_ms", "gen_tps", "prompt_tps"}
+
+const (
+	CheckPass      = "pass"
+	CheckRegressed = "regressed"
+	CheckMissing   = "missing" // The baseline has the metric but the new run doesn't
+)
+
+// Tolerances are the allowed slowdowns in percent. PerMetric keys are either a metric
+// ("ttft_ms") or a profile and metric ("code_gen.gen_tps"); the more specific key wins.
+type Tolerances struct {
+	Default   float64
+	PerMetric map[string]float64
+}
+
+// For returns the tolerance that applies to one metric of one profile.
+func (t Tolerances) For(profile, metric string) float64 {
+	if v, ok := t.PerMetric[profile+"."+metric]; ok {
+		return v
+	}
+	if v, ok := t.PerMetric[metric]; ok {
+		return v
+	}
+	return t.Default
+}
+
+// ParsePercent parses a tolerance such as "10%" or "10" into percent.
+func ParsePercent(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid tolerance %q (want a non-negative percentage such as 10%%)", s)
+	}
+	return v, nil
+}
+
+// ParseMetricTolerance parses "metric=pct" or "profile.metric=pct".
+func ParseMetricTolerance(s string) (string, float64, error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return "", 0, fmt.Errorf("invalid metric tolerance %q (want metric=10%% or profile.metric=10%%)", s)
+	}
+	metric := key
+	if profile, m, found := strings.Cut(key, "."); found {
+		if !slices.Contains(models.ProfileKeys, profile) {
+			return "", 0, fmt.Errorf("unknown profile %q in %q (want one of %s)", profile, s, strings.Join(models.ProfileKeys, ", "))
+		}
+		metric = m
+	}
+	if !slices.Contains(models.MetricKeys, metric) {
+		return "", 0, fmt.Errorf("unknown metric %q in %q (want one of %s)", metric, s, strings.Join(models.MetricKeys, ", "))
+	}
+	pct, err := ParsePercent(value)
+	if err != nil {
+		return "", 0, err
+	}
+	return key, pct, nil
+}
+
+// ValidateMetrics rejects gated metrics that aren't recorded in a results file, which
+// would otherwise be skipped without a word.
+func ValidateMetrics(metrics []string) error {
+	for _, m := range metrics {
+		if !slices.Contains(models.MetricKeys, m) {
+			return fmt.Errorf("unknown metric %q (want one of %s)", m, strings.Join(models.MetricKeys, ", "))
+		}
+	}
+	return nil
+}
+
+// MetricCheck is the gate decision for one metric.
+type MetricCheck struct {
+	MetricDiff
+	TolerancePct float64 `json:"tolerance_pct"`
+	Status       string  `json:"status"` // pass, regressed, missing
+}
+
+// CheckResult is the outcome of gating a run against a baseline.
+type CheckResult struct {
+	Baseline string        `json:"baseline"`
+	Passed   bool          `json:"passed"`
+	Failures int           `json:"failures"`
+	Notes    []string      `json:"notes,omitempty"`
+	Checks   []MetricCheck `json:"checks"`
+}
+
+// Check compares every gated metric recorded in the baseline and fails those that got
+// worse by more than their tolerance. A metric that disappeared also fails, since a
+// profile that stopped producing data usually means the run broke. It is an error
+// when the baseline records none of the gated metrics, since a gate that compared
+// nothing would pass any run.
+func Check(baseline, current *models.FullReport, metrics []string, tol Tolerances) (*CheckResult, error) {
+	if len(metrics) == 0 {
+		metrics = DefaultCheckMetrics
+	}
+	if err := ValidateMetrics(metrics); err != nil {
+		return nil, err
+	}
+	d := Compare(baseline, current, DefaultAlpha)
+	result := &CheckResult{Passed: true, Notes: d.Notes}
+
+	for _, md := range d.Metrics {
+		if md.Base == nil || !slices.Contains(metrics, md.Metric) {
+			continue
+		}
+		c := MetricCheck{MetricDiff: md, TolerancePct: tol.For(md.Profile, md.Metric), Status: CheckPass}
+		switch {
+		case md.Head == nil:
+			c.Status = CheckMissing
+		case md.Change == ChangeRegressed && worsePct(md) > c.TolerancePct:
+			c.Status = CheckRegressed
+		}
+		if c.Status != CheckPass {
+			result.Passed = false
+			result.Failures++
+		}
+		result.Checks = append(result.Checks, c)
+	}
+	if len(result.Checks) == 0 {
+		return nil, fmt.Errorf("the baseline records none of the gated metrics (%s), so nothing was checked", strings.Join(metrics, ", "))
+	}
+	return result, nil
+}
+
+// worsePct is how much worse the metric got, in percent of the baseline. Any change
+// from a zero baseline is unbounded, so it fails whatever the tolerance.
+func worsePct(md MetricDiff) float64 {
+	if md.Base.Mean == 0 && md.Delta != 0 {
+		return math.Inf(1)
+	}
+	if md.DeltaPct < 0 {
+		return -md.DeltaPct
+	}
+	return md.DeltaPct
+}
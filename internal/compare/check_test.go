@@ -0,0 +1,77 @@
+package compare
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+func TestCheck(t *testing.T) {
+	baseline := report(&models.StatsMetric{Mean: 100}, &models.StatsMetric{Mean: 40})
+	baseline.InferenceResults.Benchmarks.Summarization.Stats.PromptTPS = &models.StatsMetric{Mean: 300}
+
+	current := report(
+		&models.StatsMetric{Mean: 108}, // 8% slower, within 10%
+		&models.StatsMetric{Mean: 34},  // 15% slower
+	)
+	// Summarization prompt_tps is missing from the new run
+
+	result, err := Check(baseline, current, nil, Tolerances{Default: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Passed || result.Failures != 2 {
+		t.Fatalf("Expected 2 failures, got %+v", result)
+	}
+
+	want := map[string]string{
+		"atomic.ttft_ms":           CheckPass,
+		"code_gen.gen_tps":         CheckRegressed,
+		"summarization.prompt_tps": CheckMissing,
+	}
+	for _, c := range result.Checks {
+		if got := c.Status; got != want[c.Profile+"."+c.Metric] {
+			t.Errorf("%s.%s: expected %s, got %s", c.Profile, c.Metric, want[c.Profile+"."+c.Metric], got)
+		}
+	}
+
+	// A per-metric tolerance loosens only that metric
+	current.InferenceResults.Benchmarks.Summarization.Stats.PromptTPS = &models.StatsMetric{Mean: 300}
+	result, err = Check(baseline, current, nil, Tolerances{Default: 10, PerMetric: map[string]float64{"code_gen.gen_tps": 20}})
+	if err != nil || !result.Passed {
+		t.Errorf("Expected the looser code_gen tolerance to pass, got %+v", result.Checks)
+	}
+
+	// Any slowdown from a 0ms baseline fails, however loose the tolerance
+	baseline.InferenceResults.Benchmarks.Atomic.Stats.TTFTMs = &models.StatsMetric{Mean: 0}
+	result, err = Check(baseline, current, []string{"ttft_ms"}, Tolerances{Default: 1000})
+	if err != nil || result.Passed || len(result.Checks) != 1 || result.Checks[0].Status != CheckRegressed {
+		t.Errorf("Expected a regression from a zero baseline, got %+v", result.Checks)
+	}
+}
+
+func TestCheck_NothingToCompare(t *testing.T) {
+	baseline := report(&models.StatsMetric{Mean: 100}, &models.StatsMetric{Mean: 40})
+	current := report(&models.StatsMetric{Mean: 100}, &models.StatsMetric{Mean: 40})
+
+	if _, err := Check(baseline, current, []string{"ttft"}, Tolerances{Default: 10}); err == nil || !strings.Contains(err.Error(), "unknown metric") {
+		t.Errorf("Expected an unknown metric to be rejected, got %v", err)
+	}
+	// Neither profile records load time, so the gate would compare nothing
+	if _, err := Check(baseline, current, []string{"load_duration_ms"}, Tolerances{Default: 10}); err == nil {
+		t.Error("Expected a check that compared nothing to fail")
+	}
+}
+
+func TestParseMetricTolerance(t *testing.T) {
+	key, pct, err := ParseMetricTolerance("code_gen.gen_tps=5%")
+	if err != nil || key != "code_gen.gen_tps" || pct != 5 {
+		t.Errorf("Unexpected parse: %q %v %v", key, pct, err)
+	}
+	for _, bad := range []string{"gen_tps", "nope=5%", "nope.gen_tps=5", "ttft_ms=-1"} {
+		if _, _, err := ParseMetricTolerance(bad); err == nil {
+			t.Errorf("Expected %q to be rejected", bad)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package compare
+
+import (
+	"fmt"
+
+	"github.com/rohanelukurthy/rig-rank/internal/history"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// DefaultAlpha is the significance level below which a change is called real.
+const DefaultAlpha = 0.05
+
+const (
+	ChangeImproved  = "improved"
+	ChangeRegressed = "regressed"
+	ChangeUnchanged = "unchanged"
+	ChangeAdded     = "added"   // Only the head run has the metric
+	ChangeRemoved   = "removed" // Only the base run has the metric
+)
+
+// LowerIsBetter reports whether a smaller value of the metric is an improvement.
+func LowerIsBetter(metric string) bool {
+	switch metric {
+	case "ttft_ms", "total_duration_ms", "load_duration_ms":
+		return true
+	}
+	return false
+}
+
+// MetricDiff compares one metric of one profile between two runs.
+type MetricDiff struct {
+	Profile  string              `json:"profile"`
+	Metric   string              `json:"metric"`
+	Base     *models.StatsMetric `json:"base,omitempty"`
+	Head     *models.StatsMetric `json:"head,omitempty"`
+	Delta    float64             `json:"delta"`     // Head mean minus base mean
+	DeltaPct float64             `json:"delta_pct"` // Relative to the base mean
+	Change   string              `json:"change"`    // improved, regressed, unchanged, added, removed
+	// PValue is the two-sided Welch's t-test p-value. It is nil when either run lacks
+	// the sample count or variance needed to test, e.g. results from older versions.
+	PValue      *float64 `json:"p_value,omitempty"`
+	Significant bool     `json:"significant"`
+}
+
+// ScoreDiff compares the composite RigRank scores.
+type ScoreDiff struct {
+	Base     float64 `json:"base"`
+	Head     float64 `json:"head"`
+	Delta    float64 `json:"delta"`
+	DeltaPct float64 `json:"delta_pct"`
+	// Comparable is false when the runs used different score formula versions
+	Comparable bool `json:"comparable"`
+}
+
+// Diff is the aligned comparison of two runs.
+type Diff struct {
+	Base    string       `json:"base"` // Labels, usually the file names
+	Head    string       `json:"head"`
+	Alpha   float64      `json:"alpha"`
+	Notes   []string     `json:"notes,omitempty"` // Caveats such as different hardware or models
+	Score   *ScoreDiff   `json:"rigrank_score,omitempty"`
+	Metrics []MetricDiff `json:"metrics"`
+}
+
+// Compare aligns every profile and metric present in either run and tests whether each
+// change is larger than the run-to-run noise recorded in the samples.
+func Compare(base, head *models.FullReport, alpha float64) *Diff {
+	if alpha <= 0 {
+		alpha = DefaultAlpha
+	}
+	d := &Diff{Alpha: alpha, Notes: notes(base, head)}
+
+	for _, profile := range models.ProfileKeys {
+		baseStats := &base.InferenceResults.Benchmarks.Profile(profile).Stats
+		headStats := &head.InferenceResults.Benchmarks.Profile(profile).Stats
+		for _, metric := range models.MetricKeys {
+			bm, hm := baseStats.Metric(metric), headStats.Metric(metric)
+			if bm == nil && hm == nil {
+				continue
+			}
+			d.Metrics = append(d.Metrics, compareMetric(profile, metric, bm, hm, alpha))
+		}
+	}
+
+	if bs, hs := score(base), score(head); bs != nil && hs != nil {
+		sd := &ScoreDiff{
+			Base:       bs.Composite,
+			Head:       hs.Composite,
+			Delta:      hs.Composite - bs.Composite,
+			Comparable: bs.FormulaVersion == hs.FormulaVersion,
+		}
+		if bs.Composite != 0 {
+			sd.DeltaPct = sd.Delta / bs.Composite * 100
+		}
+		d.Score = sd
+	}
+	return d
+}
+
+func compareMetric(profile, metric string, base, head *models.StatsMetric, alpha float64) MetricDiff {
+	md := MetricDiff{Profile: profile, Metric: metric, Base: base, Head: head}
+	switch {
+	case base == nil:
+		md.Change = ChangeAdded
+		return md
+	case head == nil:
+		md.Change = ChangeRemoved
+		return md
+	}
+
+	md.Delta = head.Mean - base.Mean
+	if base.Mean != 0 {
+		md.DeltaPct = md.Delta / base.Mean * 100
+	}
+
+	better := md.Delta > 0
+	if LowerIsBetter(metric) {
+		better = md.Delta < 0
+	}
+	switch {
+	case md.Delta == 0:
+		md.Change = ChangeUnchanged
+	case better:
+		md.Change = ChangeImproved
+	default:
+		md.Change = ChangeRegressed
+	}
+
+	if base.StdDev == nil || head.StdDev == nil {
+		return md
+	}
+	if p, ok := welchTTest(base.Mean, *base.StdDev, base.N, head.Mean, *head.StdDev, head.N); ok {
+		md.PValue = &p
+		md.Significant = p < alpha
+	}
+	return md
+}
+
+func score(r *models.FullReport) *models.RigRankScore {
+	if r.UseCaseSuitability == nil || r.UseCaseSuitability.Score == nil || r.UseCaseSuitability.Score.Composite <= 0 {
+		return nil
+	}
+	return r.UseCaseSuitability.Score
+}
+
+// notes flags differences that make the comparison something other than like-for-like.
+func notes(base, head *models.FullReport) []string {
+	var n []string
+	bm, hm := base.InferenceResults.ModelMetadata, head.InferenceResults.ModelMetadata
+	if bm.Name != hm.Name {
+		n = append(n, fmt.Sprintf("Different models: %s vs %s.", bm.Name, hm.Name))
+	} else if bm.Digest != "" && hm.Digest != "" && bm.Digest != hm.Digest {
+		n = append(n, fmt.Sprintf("Same tag but different weights (digest %.12s vs %.12s).", bm.Digest, hm.Digest))
+	}
+	if bf, hf := history.Fingerprint(base.SystemInfo), history.Fingerprint(head.SystemInfo); bf != hf {
+		n = append(n, "Different hardware; deltas include the hardware change.")
+	}
+	if base.InferenceResults.MetricsVersion != head.InferenceResults.MetricsVersion {
+		n = append(n, fmt.Sprintf("Different metrics versions: %s vs %s.", base.InferenceResults.MetricsVersion, head.InferenceResults.MetricsVersion))
+	}
+	if bv, hv := ollamaVersion(base), ollamaVersion(head); bv != "" && hv != "" && bv != hv {
+		n = append(n, fmt.Sprintf("Different Ollama versions: %s vs %s.", bv, hv))
+	}
+	return n
+}
+
+func ollamaVersion(r *models.FullReport) string {
+	if r.Provenance == nil {
+		return ""
+	}
+	return r.Provenance.OllamaVersion
+}
@@ -0,0 +1,93 @@
+package compare
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+func TestStudentTwoSided(t *testing.T) {
+	tests := []struct {
+		t, df, want float64
+	}{
+		{0, 10, 1},
+		{2.228, 10, 0.05}, // Critical value for alpha 0.05
+		{1.96, 1e6, 0.05}, // Approaches the normal distribution
+		{12.706, 1, 0.05},
+	}
+	for _, tt := range tests {
+		if got := studentTwoSided(tt.t, tt.df); math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("t=%.3f df=%.0f: expected p=%.3f, got %.4f", tt.t, tt.df, tt.want, got)
+		}
+	}
+}
+
+func sd(v float64) *float64 { return &v }
+
+func report(ttft, gen *models.StatsMetric) *models.FullReport {
+	r := &models.FullReport{InferenceResults: &models.BenchmarkResult{ModelMetadata: models.ModelMetadata{Name: "llama3"}}}
+	r.InferenceResults.Benchmarks.Atomic.Stats.TTFTMs = ttft
+	r.InferenceResults.Benchmarks.CodeGen.Stats.GenTPS = gen
+	return r
+}
+
+func TestCompare(t *testing.T) {
+	base := report(
+		&models.StatsMetric{Mean: 100, StdDev: sd(5), N: 10},
+		&models.StatsMetric{Mean: 40, StdDev: sd(4), N: 3},
+	)
+	head := report(
+		&models.StatsMetric{Mean: 130, StdDev: sd(5), N: 10}, // Clearly slower
+		&models.StatsMetric{Mean: 41, StdDev: sd(4), N: 3},   // Within noise
+	)
+	head.InferenceResults.Benchmarks.Reasoning.Stats.GenTPS = &models.StatsMetric{Mean: 20}
+
+	d := Compare(base, head, 0)
+	if len(d.Metrics) != 3 {
+		t.Fatalf("Expected 3 aligned metrics, got %d", len(d.Metrics))
+	}
+
+	ttft := d.Metrics[0]
+	if ttft.Change != ChangeRegressed || !ttft.Significant || math.Abs(ttft.DeltaPct-30) > 0.001 {
+		t.Errorf("Expected a significant 30%% TTFT regression, got %+v", ttft)
+	}
+
+	gen := d.Metrics[1]
+	if gen.Change != ChangeImproved || gen.Significant || gen.PValue == nil {
+		t.Errorf("Expected an insignificant improvement, got %+v", gen)
+	}
+
+	added := d.Metrics[2]
+	if added.Change != ChangeAdded || added.PValue != nil {
+		t.Errorf("Expected reasoning gen_tps to be added without a test, got %+v", added)
+	}
+}
+
+func TestCompare_OldResultsWithoutSamples(t *testing.T) {
+	base := report(&models.StatsMetric{Mean: 100}, nil)
+	head := report(&models.StatsMetric{Mean: 50}, nil)
+
+	md := Compare(base, head, 0).Metrics[0]
+	if md.Change != ChangeImproved || md.PValue != nil || md.Significant {
+		t.Errorf("Expected an untested improvement, got %+v", md)
+	}
+}
+
+func TestCompare_NotesOllamaUpgrade(t *testing.T) {
+	base := report(&models.StatsMetric{Mean: 100}, nil)
+	head := report(&models.StatsMetric{Mean: 90}, nil)
+	base.Provenance = &models.Provenance{OllamaVersion: "0.5.7"}
+	head.Provenance = &models.Provenance{OllamaVersion: "0.6.0"}
+
+	d := Compare(base, head, 0)
+	if len(d.Notes) != 1 || d.Notes[0] != "Different Ollama versions: 0.5.7 vs 0.6.0." {
+		t.Errorf("Expected a note about the Ollama upgrade, got %v", d.Notes)
+	}
+
+	// Older results have no provenance; that alone isn't worth a note
+	base.Provenance = nil
+	if d := Compare(base, head, 0); len(d.Notes) != 0 {
+		t.Errorf("Expected no notes without provenance on both sides, got %v", d.Notes)
+	}
+}
@@ -0,0 +1,101 @@
+package compare
+
+import "math"
+
+// welchTTest returns the two-sided p-value for the difference between two sample means
+// with unequal variances. It reports false when either side has too few samples to
+// estimate its variance.
+func welchTTest(meanA, sdA float64, nA int, meanB, sdB float64, nB int) (float64, bool) {
+	if nA < 2 || nB < 2 {
+		return 0, false
+	}
+	va := sdA * sdA / float64(nA)
+	vb := sdB * sdB / float64(nB)
+	se := math.Sqrt(va + vb)
+	if se == 0 {
+		// Both runs were perfectly steady: any difference in means is real
+		if meanA == meanB {
+			return 1, true
+		}
+		return 0, true
+	}
+
+	t := (meanB - meanA) / se
+	// Welch–Satterthwaite degrees of freedom
+	df := (va + vb) * (va + vb) / (va*va/float64(nA-1) + vb*vb/float64(nB-1))
+	return studentTwoSided(t, df), true
+}
+
+// studentTwoSided is P(|T| > |t|) for Student's t distribution with df degrees of freedom.
+func studentTwoSided(t, df float64) float64 {
+	x := df / (df + t*t)
+	return regIncBeta(df/2, 0.5, x)
+}
+
+// regIncBeta is the regularized incomplete beta function I_x(a, b), evaluated with
+// the continued fraction from Numerical Recipes (betacf).
+func regIncBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	lgab, _ := math.Lgamma(a + b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log(1-x))
+
+	// The continued fraction converges fastest below the distribution's mean
+	if x < (a+1)/(a+b+2) {
+		return front * betaCF(a, b, x) / a
+	}
+	return 1 - front*betaCF(b, a, 1-x)/b
+}
+
+func betaCF(a, b, x float64) float64 {
+	const (
+		maxIter = 200
+		eps     = 3e-14
+		tiny    = 1e-300
+	)
+	qab, qap, qam := a+b, a+1, a-1
+	c, d := 1.0, 1-qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= maxIter; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+	return h
+}
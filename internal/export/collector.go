@@ -0,0 +1,71 @@
+package export
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// Collector holds the latest result of each model for the exporter's /metrics
+// endpoint, along with counters for the exporter's own runs. It is safe for
+// concurrent use.
+type Collector struct {
+	mu          sync.Mutex
+	models      []string // In first-recorded order
+	latest      map[string][]Row
+	runs        map[string]map[string]int // model → success/failure → count
+	lastSuccess map[string]time.Time
+}
+
+// NewCollector returns a collector with no results yet.
+func NewCollector() *Collector {
+	return &Collector{
+		latest:      make(map[string][]Row),
+		runs:        make(map[string]map[string]int),
+		lastSuccess: make(map[string]time.Time),
+	}
+}
+
+// Record stores the outcome of one benchmark run. A failed run keeps the model's
+// previous results so dashboards don't go blank.
+func (c *Collector) Record(model string, r *models.FullReport, err error, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.runs[model]; !ok {
+		c.models = append(c.models, model)
+		c.runs[model] = map[string]int{"success": 0, "failure": 0}
+	}
+	if err != nil || r == nil {
+		c.runs[model]["failure"]++
+		return
+	}
+	c.runs[model]["success"]++
+	c.latest[model] = Rows(r, "")
+	c.lastSuccess[model] = at
+}
+
+// ServeHTTP writes the current metrics in the OpenMetrics text format.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	c.mu.Lock()
+	s := newMetricSet()
+	for _, model := range c.models {
+		s.addRows(c.latest[model])
+	}
+	runs := s.family("rigrank_exporter_runs", "Benchmark runs by the exporter, by result.", "counter")
+	last := s.family("rigrank_exporter_last_success_timestamp_seconds", "When the model last finished a benchmark run, in seconds since the epoch.", "gauge")
+	for _, model := range c.models {
+		for _, result := range []string{"success", "failure"} {
+			runs.set(labels("model", model, "result", result), float64(c.runs[model][result]))
+		}
+		if at, ok := c.lastSuccess[model]; ok {
+			last.set(labels("model", model), float64(at.Unix()))
+		}
+	}
+	c.mu.Unlock()
+
+	w.Header().Set("Content-Type", OpenMetricsContentType)
+	s.write(w)
+}
@@ -0,0 +1,170 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/rohanelukurthy/rig-rank/internal/history"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+const (
+	FormatCSV         = "csv"
+	FormatParquet     = "parquet"
+	FormatOpenMetrics = "openmetrics"
+)
+
+// Formats lists the export formats by name.
+var Formats = []string{FormatCSV, FormatParquet, FormatOpenMetrics}
+
+// Statistics are exported for every metric. samples is the iteration count.
+var Statistics = []string{"mean", "median", "p99", "stddev", "samples"}
+
+// thinkingKeys names the ThinkingStats metrics by JSON key.
+var thinkingKeys = []string{"thinking_tokens", "answer_tokens", "time_to_first_answer_ms", "thinking_tps", "answer_tps"}
+
+// Row is one statistic of one metric of one profile, with the run, hardware and
+// model repeated on every row so files from many machines can be concatenated.
+type Row struct {
+	RunID        string     `parquet:"run_id"`
+	Timestamp    *time.Time `parquet:"timestamp,optional,timestamp(millisecond)"`
+	Hostname     string     `parquet:"hostname"`
+	Fingerprint  string     `parquet:"hardware_fingerprint"`
+	Arch         string     `parquet:"arch"`
+	CPUModel     string     `parquet:"cpu_model"`
+	CPUCores     int64      `parquet:"cpu_cores"`
+	RAMMB        int64      `parquet:"ram_mb"`
+	GPUModel     string     `parquet:"gpu_model"`
+	VRAMMB       int64      `parquet:"vram_mb"`
+	Model        string     `parquet:"model"`
+	ModelDigest  string     `parquet:"model_digest"`
+	Quantization string     `parquet:"quantization"`
+	Profile      string     `parquet:"profile"`
+	Metric       string     `parquet:"metric"`
+	Statistic    string     `parquet:"statistic"`
+	Value        float64    `parquet:"value"`
+}
+
+// Columns is the CSV header, matching the parquet column names.
+var Columns = []string{
+	"run_id", "timestamp", "hostname", "hardware_fingerprint", "arch", "cpu_model", "cpu_cores", "ram_mb",
+	"gpu_model", "vram_mb", "model", "model_digest", "quantization", "profile", "metric", "statistic", "value",
+}
+
+// Rows flattens a report into tidy long-format rows. Metrics without data produce no
+// rows. fallbackID names the run when the report predates provenance.
+func Rows(r *models.FullReport, fallbackID string) []Row {
+	base := Row{RunID: fallbackID}
+	if p := r.Provenance; p != nil {
+		if p.RunID != "" {
+			base.RunID = p.RunID
+		}
+		if !p.Timestamp.IsZero() {
+			ts := p.Timestamp
+			base.Timestamp = &ts
+		}
+		base.Hostname = p.Host.Hostname
+	}
+	if sys := r.SystemInfo; sys != nil {
+		base.Fingerprint = history.Fingerprint(sys)
+		base.Arch = sys.Arch
+		base.CPUModel = sys.CPU.Model
+		base.CPUCores = int64(sys.CPU.CoresLogical)
+		base.RAMMB = int64(sys.RAM.TotalMB)
+		base.GPUModel = sys.GPU.Model
+		base.VRAMMB = int64(sys.GPU.VRAMTotalMB)
+	}
+	meta := r.InferenceResults.ModelMetadata
+	base.Model, base.ModelDigest, base.Quantization = meta.Name, meta.Digest, meta.Quantization
+
+	var rows []Row
+	add := func(profile, metric string, m *models.StatsMetric) {
+		for _, stat := range Statistics {
+			if v, ok := statistic(m, stat); ok {
+				row := base
+				row.Profile, row.Metric, row.Statistic, row.Value = profile, metric, stat, v
+				rows = append(rows, row)
+			}
+		}
+	}
+	for _, profile := range models.ProfileKeys {
+		p := r.InferenceResults.Benchmarks.Profile(profile)
+		for _, metric := range models.MetricKeys {
+			add(profile, metric, p.Stats.Metric(metric))
+		}
+		if t := p.Thinking; t != nil {
+			for i, m := range []*models.StatsMetric{t.ThinkingTokens, t.AnswerTokens, t.TimeToFirstAnswerMs, t.ThinkingTPS, t.AnswerTPS} {
+				add(profile, thinkingKeys[i], m)
+			}
+		}
+	}
+	return rows
+}
+
+// statistic extends StatsMetric.Statistic with the spread and sample count, which
+// are missing from results recorded before they were tracked.
+func statistic(m *models.StatsMetric, key string) (float64, bool) {
+	if m == nil {
+		return 0, false
+	}
+	switch key {
+	case "stddev":
+		if m.StdDev == nil {
+			return 0, false
+		}
+		return *m.StdDev, true
+	case "samples":
+		return float64(m.N), m.N > 0
+	}
+	return m.Statistic(key)
+}
+
+// Write encodes rows in the given format. header only applies to CSV, so several
+// runs can be appended to one file.
+func Write(w io.Writer, format string, rows []Row, header bool) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, rows, header)
+	case FormatParquet:
+		return WriteParquet(w, rows)
+	case FormatOpenMetrics:
+		return WriteOpenMetrics(w, rows)
+	}
+	return fmt.Errorf("unknown export format %q (want one of %s)", format, strings.Join(Formats, ", "))
+}
+
+// WriteCSV writes rows as CSV, optionally preceded by the header.
+func WriteCSV(w io.Writer, rows []Row, header bool) error {
+	cw := csv.NewWriter(w)
+	if header {
+		cw.Write(Columns)
+	}
+	for _, r := range rows {
+		ts := ""
+		if r.Timestamp != nil {
+			ts = r.Timestamp.UTC().Format(time.RFC3339)
+		}
+		cw.Write([]string{
+			r.RunID, ts, r.Hostname, r.Fingerprint, r.Arch, r.CPUModel,
+			strconv.FormatInt(r.CPUCores, 10), strconv.FormatInt(r.RAMMB, 10),
+			r.GPUModel, strconv.FormatInt(r.VRAMMB, 10), r.Model, r.ModelDigest, r.Quantization,
+			r.Profile, r.Metric, r.Statistic, strconv.FormatFloat(r.Value, 'g', -1, 64),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteParquet writes rows as a single parquet file.
+func WriteParquet(w io.Writer, rows []Row) error {
+	pw := parquet.NewGenericWriter[Row](w)
+	if _, err := pw.Write(rows); err != nil {
+		return err
+	}
+	return pw.Close()
+}
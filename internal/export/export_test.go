@@ -0,0 +1,72 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/rohanelukurthy/rig-rank/internal/reporttest"
+)
+
+func TestRows(t *testing.T) {
+	rows := Rows(reporttest.Report(t), "fallback")
+	if len(rows) != 18 {
+		t.Fatalf("Expected 5 statistics for each of 3 metrics and 3 for the migrated gen_tps, got %d", len(rows))
+	}
+	first := rows[0]
+	if first.RunID != "abc123" || first.Hostname != "lab-07" || first.CPUModel != "Apple M3" || first.RAMMB != 16384 ||
+		first.Model != "llama3" || first.Profile != "atomic" || first.Metric != "ttft_ms" || first.Statistic != "mean" || first.Value != 100 {
+		t.Errorf("Unexpected first row: %+v", first)
+	}
+	if first.Fingerprint == "" {
+		t.Error("Expected the hardware fingerprint on every row")
+	}
+
+	old := reporttest.Report(t)
+	old.Provenance = nil
+	if rows := Rows(old, "20260301-120000"); rows[0].RunID != "20260301-120000" || rows[0].Timestamp != nil {
+		t.Errorf("Expected the fallback ID without provenance, got %+v", rows[0])
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, Rows(reporttest.Report(t), ""), true); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 19 || len(records[0]) != len(Columns) || records[0][0] != "run_id" {
+		t.Fatalf("Expected a header and 18 rows, got %v", records)
+	}
+	if got := records[5]; got[1] != "2026-03-01T12:00:00Z" || got[15] != "samples" || got[16] != "5" {
+		t.Errorf("Unexpected samples row: %v", got)
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	var buf bytes.Buffer
+	want := Rows(reporttest.Report(t), "")
+	if err := Write(&buf, FormatParquet, want, true); err != nil {
+		t.Fatal(err)
+	}
+	got, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d rows back, got %d", len(want), len(got))
+	}
+	if got[3].Statistic != "stddev" || got[3].Value != 1.5 || got[3].Timestamp == nil || !got[3].Timestamp.Equal(*want[3].Timestamp) {
+		t.Errorf("Expected the stddev row to round-trip, got %+v", got[3])
+	}
+}
+
+func TestWrite_UnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, "xlsx", nil, true); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,124 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// OpenMetricsContentType is served on /metrics by the exporter.
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// metricHelp describes each exported metric family.
+var metricHelp = map[string]string{
+	"ttft_ms":                 "Time to first token in milliseconds.",
+	"gen_tps":                 "Generation speed in tokens per second.",
+	"prompt_tps":              "Prompt processing speed in tokens per second.",
+	"total_duration_ms":       "Total request duration in milliseconds.",
+	"load_duration_ms":        "Model load time per request in milliseconds.",
+	"chars_per_sec":           "Generated characters per second.",
+	"words_per_sec":           "Generated words per second.",
+	"thinking_tokens":         "Tokens spent reasoning before the answer.",
+	"answer_tokens":           "Tokens in the visible answer.",
+	"time_to_first_answer_ms": "Time until the first answer token in milliseconds, including thinking.",
+	"thinking_tps":            "Reasoning speed in tokens per second.",
+	"answer_tps":              "Answer speed in tokens per second.",
+}
+
+// series is one labelled sample of a metric family.
+type series struct {
+	labels string
+	value  float64
+}
+
+// family collects the samples of one metric, in first-seen order.
+type family struct {
+	name, help, typ string
+	series          []series
+	index           map[string]int
+}
+
+func (f *family) set(labels string, value float64) {
+	if i, ok := f.index[labels]; ok {
+		f.series[i].value = value
+		return
+	}
+	f.index[labels] = len(f.series)
+	f.series = append(f.series, series{labels, value})
+}
+
+// metricSet groups samples into families so each family is written once.
+type metricSet struct {
+	families []*family
+	byName   map[string]*family
+}
+
+func newMetricSet() *metricSet {
+	return &metricSet{byName: make(map[string]*family)}
+}
+
+func (s *metricSet) family(name, help, typ string) *family {
+	f, ok := s.byName[name]
+	if !ok {
+		f = &family{name: name, help: help, typ: typ, index: make(map[string]int)}
+		s.families = append(s.families, f)
+		s.byName[name] = f
+	}
+	return f
+}
+
+// addRows adds every row as a gauge, one family per metric with the statistic as a
+// label, plus the time of each run.
+func (s *metricSet) addRows(rows []Row) {
+	for _, r := range rows {
+		if r.Timestamp != nil {
+			s.family("rigrank_run_timestamp_seconds", "When the benchmark run started, in seconds since the epoch.", "gauge").
+				set(labels("model", r.Model, "quantization", r.Quantization, "host", r.Hostname), float64(r.Timestamp.Unix()))
+		}
+		s.family("rigrank_"+r.Metric, metricHelp[r.Metric], "gauge").
+			set(labels("model", r.Model, "profile", r.Profile, "quantization", r.Quantization, "host", r.Hostname, "statistic", r.Statistic), r.Value)
+	}
+}
+
+// write encodes the families followed by the mandatory # EOF marker. Samples carry
+// no timestamps, which the node_exporter textfile collector rejects.
+func (s *metricSet) write(w io.Writer) error {
+	b := strings.Builder{}
+	for _, f := range s.families {
+		if f.help != "" {
+			fmt.Fprintf(&b, "# HELP %s %s\n", f.name, f.help)
+		}
+		fmt.Fprintf(&b, "# TYPE %s %s\n", f.name, f.typ)
+		sample := f.name
+		if f.typ == "counter" {
+			sample += "_total"
+		}
+		for _, ser := range f.series {
+			fmt.Fprintf(&b, "%s{%s} %s\n", sample, ser.labels, strconv.FormatFloat(ser.value, 'f', -1, 64))
+		}
+	}
+	b.WriteString("# EOF\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteOpenMetrics writes rows in the OpenMetrics text format, suitable for the
+// node_exporter textfile collector. Series are labelled by model, profile,
+// quantization, host and statistic; when several runs share a series the last
+// one wins, so pass runs oldest first.
+func WriteOpenMetrics(w io.Writer, rows []Row) error {
+	s := newMetricSet()
+	s.addRows(rows)
+	return s.write(w)
+}
+
+// labels renders name/value pairs as an OpenMetrics label set.
+func labels(pairs ...string) string {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, pairs[i], escape.Replace(pairs[i+1])))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,80 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/reporttest"
+)
+
+func TestWriteOpenMetrics(t *testing.T) {
+	older := reporttest.Report(t)
+	older.InferenceResults.Benchmarks.Atomic.Stats.TTFTMs.Mean = 500
+	rows := append(Rows(older, ""), Rows(reporttest.Report(t), "")...)
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatOpenMetrics, rows, true); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	want := `rigrank_ttft_ms{model="llama3",profile="atomic",quantization="Q4_0",host="lab-07",statistic="mean"} 100`
+	if !strings.Contains(out, want+"\n") {
+		t.Errorf("Expected the latest run's series %s, got:\n%s", want, out)
+	}
+	if strings.Count(out, `statistic="mean"} `) != 4 {
+		t.Errorf("Expected one mean series per profile metric after deduplication, got:\n%s", out)
+	}
+	if strings.Count(out, "# TYPE rigrank_ttft_ms gauge") != 1 {
+		t.Errorf("Expected each family to be declared once, got:\n%s", out)
+	}
+	if !strings.Contains(out, `rigrank_run_timestamp_seconds{model="llama3",quantization="Q4_0",host="lab-07"} 1772366400`) {
+		t.Errorf("Expected the run timestamp, got:\n%s", out)
+	}
+	if !strings.HasSuffix(out, "# EOF\n") {
+		t.Errorf("Expected the # EOF terminator, got:\n%s", out)
+	}
+}
+
+func TestLabels_Escape(t *testing.T) {
+	if got := labels("model", `we"ird\name`+"\n"); got != `model="we\"ird\\name\n"` {
+		t.Errorf("Unexpected escaping: %s", got)
+	}
+}
+
+func TestCollector(t *testing.T) {
+	c := NewCollector()
+	at := time.Date(2026, 3, 1, 13, 0, 0, 0, time.UTC)
+	c.Record("llama3", reporttest.Report(t), nil, at)
+	c.Record("llama3", nil, errors.New("ollama went away"), at.Add(time.Hour))
+
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+	resp, err := srv.Client().Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	out := string(body)
+
+	if ct := resp.Header.Get("Content-Type"); ct != OpenMetricsContentType {
+		t.Errorf("Expected the OpenMetrics content type, got %q", ct)
+	}
+	for _, want := range []string{
+		`rigrank_gen_tps{model="llama3",profile="code_gen",quantization="Q4_0",host="lab-07",statistic="mean"} 40.5`,
+		"# TYPE rigrank_exporter_runs counter",
+		`rigrank_exporter_runs_total{model="llama3",result="success"} 1`,
+		`rigrank_exporter_runs_total{model="llama3",result="failure"} 1`,
+		`rigrank_exporter_last_success_timestamp_seconds{model="llama3"} 1772370000`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("Expected %s, got:\n%s", want, out)
+		}
+	}
+}
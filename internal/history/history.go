@@ -0,0 +1,284 @@
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/report"
+)
+
+// DirEnv overrides the history location, mainly for CI and tests.
+const DirEnv = "RIGRANK_HOME"
+
+const (
+	indexFile = "index.json"
+	runsDir   = "runs"
+	// idTimeFormat sorts lexically in time order and is safe in file names on every OS
+	idTimeFormat = "20060102-150405"
+)
+
+// ErrNotFound is returned when no stored run matches an ID.
+var ErrNotFound = errors.New("run not found in history")
+
+// Entry indexes one stored run.
+type Entry struct {
+	ID          string    `json:"id"`
+	Timestamp   time.Time `json:"timestamp"`
+	Model       string    `json:"model"`
+	ModelDigest string    `json:"model_digest,omitempty"`
+	Fingerprint string    `json:"hardware_fingerprint"`
+	Hardware    string    `json:"hardware"` // Human-readable summary of the fingerprinted hardware
+	Score       float64   `json:"rigrank_score,omitempty"`
+}
+
+// Store keeps full reports on disk with an index for listing them.
+type Store struct {
+	Dir string
+}
+
+// DefaultDir returns the per-user data directory for RigRank history:
+// $RIGRANK_HOME, else $XDG_DATA_HOME/rigrank, else the platform's usual location.
+func DefaultDir() (string, error) {
+	if dir := os.Getenv(DirEnv); dir != "" {
+		return dir, nil
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "rigrank"), nil
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		if dir := os.Getenv("LocalAppData"); dir != "" {
+			return filepath.Join(dir, "rigrank"), nil
+		}
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, "Library", "Application Support", "rigrank"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "rigrank"), nil
+}
+
+// Open returns the store in the default directory.
+func Open() (*Store, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate history directory: %w", err)
+	}
+	return &Store{Dir: dir}, nil
+}
+
+// Fingerprint identifies the hardware a run used. It covers the parts that bound
+// inference speed, so a driver or Ollama upgrade keeps the fingerprint while a new
+// GPU or RAM upgrade changes it.
+func Fingerprint(sys *models.SystemInfo) string {
+	if sys == nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(hardwareSummary(sys)))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func hardwareSummary(sys *models.SystemInfo) string {
+	if sys == nil {
+		return ""
+	}
+	parts := []string{sys.Arch, sys.CPU.Model, fmt.Sprintf("%dc", sys.CPU.CoresLogical), fmt.Sprintf("%dMB RAM", sys.RAM.TotalMB)}
+	if sys.GPU.Model != "" {
+		parts = append(parts, sys.GPU.Model)
+	}
+	if sys.GPU.VRAMTotalMB > 0 {
+		parts = append(parts, fmt.Sprintf("%dMB VRAM", sys.GPU.VRAMTotalMB))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Save stores a report taken at the given time and returns its index entry.
+func (s *Store) Save(full *models.FullReport, at time.Time) (Entry, error) {
+	if err := os.MkdirAll(filepath.Join(s.Dir, runsDir), 0755); err != nil {
+		return Entry{}, fmt.Errorf("failed to create history directory: %w", err)
+	}
+	entries, err := s.List()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	entry := Entry{
+		Timestamp:   at.UTC(),
+		Fingerprint: Fingerprint(full.SystemInfo),
+		Hardware:    hardwareSummary(full.SystemInfo),
+	}
+	if r := full.InferenceResults; r != nil {
+		entry.Model = r.ModelMetadata.Name
+		entry.ModelDigest = r.ModelMetadata.Digest
+	}
+	if u := full.UseCaseSuitability; u != nil && u.Score != nil {
+		entry.Score = u.Score.Composite
+	}
+	entry.ID = uniqueID(entries, at.UTC().Format(idTimeFormat))
+
+	data, err := json.MarshalIndent(full, "", "  ")
+	if err != nil {
+		return Entry{}, err
+	}
+	if err := writeFileAtomic(s.runPath(entry.ID), data); err != nil {
+		return Entry{}, fmt.Errorf("failed to save run: %w", err)
+	}
+
+	entries = append(entries, entry)
+	if err := s.writeIndex(entries); err != nil {
+		return Entry{}, err
+	}
+	return entry, nil
+}
+
+// List returns every stored run, oldest first.
+func (s *Store) List() ([]Entry, error) {
+	data, err := os.ReadFile(filepath.Join(s.Dir, indexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history index: %w", err)
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("history index is corrupt: %w", err)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+	return entries, nil
+}
+
+// Find resolves a run ID or a unique prefix of one, so IDs can be abbreviated.
+func (s *Store) Find(id string) (Entry, error) {
+	entries, err := s.List()
+	if err != nil {
+		return Entry{}, err
+	}
+	var matches []Entry
+	for _, e := range entries {
+		if e.ID == id {
+			return e, nil
+		}
+		if strings.HasPrefix(e.ID, id) {
+			matches = append(matches, e)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Entry{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	case 1:
+		return matches[0], nil
+	}
+	return Entry{}, fmt.Errorf("run ID %q is ambiguous (%d matches)", id, len(matches))
+}
+
+// Load reads the full report of a stored run.
+func (s *Store) Load(id string) (*models.FullReport, Entry, error) {
+	entry, err := s.Find(id)
+	if err != nil {
+		return nil, Entry{}, err
+	}
+	data, err := os.ReadFile(s.runPath(entry.ID))
+	if err != nil {
+		return nil, Entry{}, fmt.Errorf("failed to read run %s: %w", entry.ID, err)
+	}
+	r, err := report.Parse(data)
+	if err != nil {
+		return nil, Entry{}, fmt.Errorf("run %s: %w", entry.ID, err)
+	}
+	return r, entry, nil
+}
+
+// Remove deletes a stored run and its index entry.
+func (s *Store) Remove(id string) (Entry, error) {
+	entry, err := s.Find(id)
+	if err != nil {
+		return Entry{}, err
+	}
+	entries, err := s.List()
+	if err != nil {
+		return Entry{}, err
+	}
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.ID != entry.ID {
+			kept = append(kept, e)
+		}
+	}
+	if err := s.writeIndex(kept); err != nil {
+		return Entry{}, err
+	}
+	if err := os.Remove(s.runPath(entry.ID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Entry{}, fmt.Errorf("failed to delete run %s: %w", entry.ID, err)
+	}
+	return entry, nil
+}
+
+func (s *Store) runPath(id string) string {
+	return filepath.Join(s.Dir, runsDir, id+".json")
+}
+
+func (s *Store) writeIndex(entries []Entry) error {
+	if entries == nil {
+		entries = []Entry{}
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(s.Dir, indexFile), data); err != nil {
+		return fmt.Errorf("failed to write history index: %w", err)
+	}
+	return nil
+}
+
+// uniqueID appends a counter when two runs are saved within the same second.
+func uniqueID(entries []Entry, base string) string {
+	taken := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		taken[e.ID] = true
+	}
+	id := base
+	for n := 2; taken[id]; n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	return id
+}
+
+// writeFileAtomic replaces path in one step so an interrupted write can't corrupt it.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,93 @@
+package history
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+func testReport(model, gpu string) *models.FullReport {
+	return &models.FullReport{
+		SystemInfo: &models.SystemInfo{
+			Arch: "amd64",
+			CPU:  models.CPU{Model: "AMD Ryzen 7 7700X", CoresLogical: 16},
+			GPU:  models.GPU{Model: gpu, VRAMTotalMB: 12288},
+			RAM:  models.RAM{TotalMB: 32768},
+		},
+		InferenceResults: &models.BenchmarkResult{
+			ModelMetadata: models.ModelMetadata{Name: model, Digest: "365c0bd3c000"},
+		},
+		UseCaseSuitability: &models.SuitabilityReport{
+			Score: &models.RigRankScore{Composite: 123.4},
+		},
+	}
+}
+
+func TestStore_SaveListLoadRemove(t *testing.T) {
+	store := &Store{Dir: t.TempDir()}
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	first, err := store.Save(testReport("llama3", "RTX 4070"), at)
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	// A second run in the same second still gets its own ID
+	second, err := store.Save(testReport("qwen3:8b", "RTX 4070"), at)
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if first.ID != "20260301-120000" || second.ID != "20260301-120000-2" {
+		t.Errorf("Unexpected IDs %q and %q", first.ID, second.ID)
+	}
+	if first.ModelDigest != "365c0bd3c000" || first.Score != 123.4 {
+		t.Errorf("Expected digest and score in the index, got %+v", first)
+	}
+	if first.Fingerprint == "" || first.Fingerprint != second.Fingerprint {
+		t.Errorf("Expected the same hardware to share a fingerprint, got %q and %q", first.Fingerprint, second.Fingerprint)
+	}
+
+	entries, err := store.List()
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d (%v)", len(entries), err)
+	}
+
+	report, entry, err := store.Load(second.ID)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if entry.ID != second.ID || report.InferenceResults.ModelMetadata.Name != "qwen3:8b" {
+		t.Errorf("Loaded the wrong run: %+v", entry)
+	}
+
+	if _, err := store.Find("20260301"); err == nil {
+		t.Error("Expected an ambiguous prefix to be rejected")
+	}
+
+	if _, err := store.Remove(second.ID); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, _, err := store.Load(second.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected removed run to be gone, got %v", err)
+	}
+	// With one run left the prefix is unique
+	if e, err := store.Find("20260301"); err != nil || e.ID != first.ID {
+		t.Errorf("Expected prefix to resolve to %s, got %q (%v)", first.ID, e.ID, err)
+	}
+}
+
+func TestFingerprint_ChangesWithHardware(t *testing.T) {
+	a := Fingerprint(testReport("llama3", "RTX 4070").SystemInfo)
+	b := Fingerprint(testReport("llama3", "RTX 4090").SystemInfo)
+	if a == b {
+		t.Error("Expected a different GPU to change the fingerprint")
+	}
+}
+
+func TestStore_ListEmpty(t *testing.T) {
+	entries, err := (&Store{Dir: t.TempDir()}).List()
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Expected an empty history, got %v (%v)", entries, err)
+	}
+}
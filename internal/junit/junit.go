@@ -0,0 +1,206 @@
+// Package junit renders benchmark results as JUnit XML, so CI systems such as Jenkins
+// and GitLab show them in their test UI.
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/rohanelukurthy/rig-rank/internal/compare"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/scoring"
+)
+
+// FailureThreshold is the failure type of a regression threshold violation. POOR
+// ratings use the rating as their type.
+const FailureThreshold = "threshold"
+
+// Suites is the <testsuites> document root.
+type Suites struct {
+	XMLName  xml.Name `xml:"testsuites"`
+	Name     string   `xml:"name,attr"`
+	Tests    int      `xml:"tests,attr"`
+	Failures int      `xml:"failures,attr"`
+	Skipped  int      `xml:"skipped,attr"`
+	Time     string   `xml:"time,attr"`
+	Suites   []Suite  `xml:"testsuite"`
+}
+
+// Suite holds the testcases of one run.
+type Suite struct {
+	Name       string     `xml:"name,attr"`
+	Tests      int        `xml:"tests,attr"`
+	Failures   int        `xml:"failures,attr"`
+	Errors     int        `xml:"errors,attr"`
+	Skipped    int        `xml:"skipped,attr"`
+	Time       string     `xml:"time,attr"`
+	Timestamp  string     `xml:"timestamp,attr,omitempty"`
+	Hostname   string     `xml:"hostname,attr,omitempty"`
+	Properties []Property `xml:"properties>property,omitempty"`
+	Cases      []TestCase `xml:"testcase"`
+	SystemOut  string     `xml:"system-out,omitempty"`
+
+	seconds float64
+}
+
+type Property struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr"`
+	Time      string   `xml:"time,attr,omitempty"`
+	Failure   *Failure `xml:"failure,omitempty"`
+	Skipped   *Skipped `xml:"skipped,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+}
+
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type Skipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// FromReport builds the suite for one run: a testcase per profile, timed by the
+// total duration of its measured requests (untimed when the sample count wasn't
+// recorded), and a testcase per use case that fails on a POOR rating with the
+// rating's reason. When check is non-nil, each metric that broke its regression
+// threshold fails its profile's testcase.
+func FromReport(r *models.FullReport, check *compare.CheckResult) Suite {
+	res := r.InferenceResults
+	model := res.ModelMetadata.Name
+	suite := Suite{Name: "rigrank." + model}
+	suite.Properties = appendProperty(suite.Properties, "model", model)
+	suite.Properties = appendProperty(suite.Properties, "quantization", res.ModelMetadata.Quantization)
+	suite.Properties = appendProperty(suite.Properties, "metrics_version", res.MetricsVersion)
+	if p := r.Provenance; p != nil {
+		if !p.Timestamp.IsZero() {
+			suite.Timestamp = p.Timestamp.UTC().Format("2006-01-02T15:04:05")
+		}
+		suite.Hostname = p.Host.Hostname
+		suite.Properties = appendProperty(suite.Properties, "run_id", p.RunID)
+		suite.Properties = appendProperty(suite.Properties, "ollama_version", p.OllamaVersion)
+	}
+	if u := r.UseCaseSuitability; u != nil && u.Score != nil && u.Score.Composite > 0 {
+		suite.Properties = appendProperty(suite.Properties, "rigrank_score", fmt.Sprintf("%.1f", u.Score.Composite))
+	}
+
+	violations := make(map[string][]string)
+	if check != nil {
+		for _, c := range check.Checks {
+			switch c.Status {
+			case compare.CheckRegressed:
+				change := fmt.Sprintf("%+.1f%%", c.DeltaPct)
+				if c.Base != nil && c.Head != nil && c.Base.Mean == 0 {
+					change = fmt.Sprintf("from 0 to %.1f", c.Head.Mean)
+				}
+				violations[c.Profile] = append(violations[c.Profile], fmt.Sprintf("%s regressed %s against the baseline (tolerance %.0f%%)", c.Metric, change, c.TolerancePct))
+			case compare.CheckMissing:
+				violations[c.Profile] = append(violations[c.Profile], c.Metric+" is missing but the baseline has it")
+			}
+		}
+		suite.SystemOut = strings.Join(check.Notes, "\n")
+	}
+
+	for _, key := range models.ProfileKeys {
+		stats := &res.Benchmarks.Profile(key).Stats
+		tc := TestCase{Name: key, ClassName: suite.Name + ".profiles"}
+		if m := stats.TotalDurationMs; m != nil && m.N > 0 {
+			total := m.Mean * float64(m.N) / 1000
+			tc.Time = seconds(total)
+			suite.seconds += total
+		}
+		tc.SystemOut = timings(stats)
+		switch {
+		case len(violations[key]) > 0:
+			tc.Failure = &Failure{Message: violations[key][0], Type: FailureThreshold, Text: strings.Join(violations[key], "\n")}
+		case tc.SystemOut == "":
+			tc.Skipped = &Skipped{Message: "no samples were recorded"}
+		}
+		suite.add(tc)
+	}
+
+	if u := r.UseCaseSuitability; u != nil {
+		for _, key := range models.UseCaseKeys {
+			s := u.UseCase(key)
+			tc := TestCase{Name: key, ClassName: suite.Name + ".use_cases", Time: seconds(0), SystemOut: s.Rating + ": " + s.Reason}
+			switch s.Rating {
+			case scoring.RatingPoor:
+				tc.Failure = &Failure{Message: s.Reason, Type: scoring.RatingPoor, Text: s.Reason}
+			case scoring.RatingInsufficientData:
+				tc.Skipped = &Skipped{Message: s.Reason}
+			}
+			suite.add(tc)
+		}
+	}
+
+	suite.Time = seconds(suite.seconds)
+	return suite
+}
+
+func (s *Suite) add(tc TestCase) {
+	s.Tests++
+	switch {
+	case tc.Failure != nil:
+		s.Failures++
+	case tc.Skipped != nil:
+		s.Skipped++
+	}
+	s.Cases = append(s.Cases, tc)
+}
+
+// Write encodes the suites as one indented JUnit XML document.
+func Write(w io.Writer, suites ...Suite) error {
+	doc := Suites{Name: "rigrank"}
+	var total float64
+	for _, s := range suites {
+		doc.Tests += s.Tests
+		doc.Failures += s.Failures
+		doc.Skipped += s.Skipped
+		total += s.seconds
+	}
+	doc.Time = seconds(total)
+	doc.Suites = suites
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// timings lists a profile's recorded latency and throughput for the testcase output.
+func timings(s *models.Stats) string {
+	var lines []string
+	for _, key := range models.MetricKeys {
+		if m := s.Metric(key); m != nil {
+			lines = append(lines, fmt.Sprintf("%s: mean %.1f, median %.1f, p99 %.1f", key, m.Mean, m.Median, m.P99))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func appendProperty(props []Property, name, value string) []Property {
+	if value == "" {
+		return props
+	}
+	return append(props, Property{name, value})
+}
+
+func seconds(s float64) string {
+	return fmt.Sprintf("%.3f", math.Max(s, 0))
+}
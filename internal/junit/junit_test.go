@@ -0,0 +1,80 @@
+package junit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/rohanelukurthy/rig-rank/internal/compare"
+	"github.com/rohanelukurthy/rig-rank/internal/reporttest"
+)
+
+func decode(t *testing.T, suites ...Suite) Suites {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Write(&buf, suites...); err != nil {
+		t.Fatal(err)
+	}
+	var doc Suites
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Expected valid XML, got %v:\n%s", err, buf.String())
+	}
+	return doc
+}
+
+func TestFromReport(t *testing.T) {
+	doc := decode(t, FromReport(reporttest.Report(t), nil))
+	if doc.Tests != 10 || doc.Failures != 1 || doc.Skipped != 4 {
+		t.Errorf("Expected 10 tests, 1 failure and 4 skipped (3 empty profiles, 1 insufficient), got %d/%d/%d", doc.Tests, doc.Failures, doc.Skipped)
+	}
+	if doc.Time != "113.750" {
+		t.Errorf("Expected the suite time to add up the profiles, got %s", doc.Time)
+	}
+
+	cases := map[string]TestCase{}
+	for _, tc := range doc.Suites[0].Cases {
+		cases[tc.ClassName+"/"+tc.Name] = tc
+	}
+	coding := cases["rigrank.llama3.use_cases/coding"]
+	if coding.Failure == nil || coding.Failure.Type != "POOR" || coding.Failure.Message != "Generation speed of 40.5 t/s is below the policy's 60 t/s." {
+		t.Errorf("Expected the POOR rating to fail with its reason, got %+v", coding.Failure)
+	}
+	codeGen := cases["rigrank.llama3.profiles/code_gen"]
+	if codeGen.Time != "112.500" || codeGen.Failure != nil || codeGen.SystemOut == "" {
+		t.Errorf("Expected a passing, timed profile with its timings, got %+v", codeGen)
+	}
+}
+
+func TestFromReport_UnknownSampleCount(t *testing.T) {
+	r := reporttest.Report(t)
+	r.InferenceResults.Benchmarks.CodeGen.Stats.TotalDurationMs.N = 0 // Recorded before samples were counted
+	doc := decode(t, FromReport(r, nil))
+	for _, tc := range doc.Suites[0].Cases {
+		if tc.Name == "code_gen" && tc.Time != "" {
+			t.Errorf("Expected no time without a sample count, got %s", tc.Time)
+		}
+	}
+	if doc.Time != "1.250" {
+		t.Errorf("Expected the suite time to count only timed profiles, got %s", doc.Time)
+	}
+}
+
+func TestFromReport_ThresholdViolations(t *testing.T) {
+	check := &compare.CheckResult{Checks: []compare.MetricCheck{
+		{MetricDiff: compare.MetricDiff{Profile: "code_gen", Metric: "gen_tps", DeltaPct: -25}, TolerancePct: 10, Status: compare.CheckRegressed},
+		{MetricDiff: compare.MetricDiff{Profile: "atomic", Metric: "ttft_ms", DeltaPct: 3}, TolerancePct: 10, Status: compare.CheckPass},
+		{MetricDiff: compare.MetricDiff{Profile: "reasoning", Metric: "gen_tps"}, TolerancePct: 10, Status: compare.CheckMissing},
+	}}
+	doc := decode(t, FromReport(reporttest.Report(t), check))
+	if doc.Failures != 3 {
+		t.Errorf("Expected the POOR rating and two violations to fail, got %d failures", doc.Failures)
+	}
+	for _, tc := range doc.Suites[0].Cases {
+		if tc.Name == "code_gen" && (tc.Failure == nil || tc.Failure.Type != FailureThreshold || tc.Failure.Message != "gen_tps regressed -25.0% against the baseline (tolerance 10%)") {
+			t.Errorf("Unexpected code_gen failure: %+v", tc.Failure)
+		}
+		if tc.Name == "reasoning" && tc.ClassName == "rigrank.llama3.profiles" && (tc.Failure == nil || tc.Skipped != nil) {
+			t.Errorf("Expected the missing metric to fail rather than skip, got %+v", tc)
+		}
+	}
+}
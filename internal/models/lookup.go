@@ -0,0 +1,102 @@
+package models
+
+// ProfileKeys lists the benchmark profiles by JSON key, in suite order.
+var ProfileKeys = []string{"atomic", "code_gen", "story_gen", "summarization", "reasoning"}
+
+// MetricKeys lists the Stats metrics by JSON key.
+var MetricKeys = []string{"ttft_ms", "gen_tps", "prompt_tps", "total_duration_ms", "load_duration_ms", "chars_per_sec", "words_per_sec"}
+
+// StatisticKeys lists the aggregates recorded for every metric.
+var StatisticKeys = []string{"mean", "median", "p99"}
+
+// UseCaseKeys lists the suitability use cases by JSON key.
+var UseCaseKeys = []string{"quick_qa", "coding", "writing", "summarization", "data_analysis"}
+
+// Profile returns the profile stored under a JSON key, or nil if the key is unknown.
+func (b *Benchmarks) Profile(key string) *ProfileStats {
+	switch key {
+	case "atomic":
+		return &b.Atomic
+	case "code_gen":
+		return &b.CodeGen
+	case "story_gen":
+		return &b.StoryGen
+	case "summarization":
+		return &b.Summarization
+	case "reasoning":
+		return &b.Reasoning
+	}
+	return nil
+}
+
+// Metric returns the metric stored under a JSON key. It is nil when the key is
+// unknown or the metric had no samples.
+func (s *Stats) Metric(key string) *StatsMetric {
+	switch key {
+	case "ttft_ms":
+		return s.TTFTMs
+	case "gen_tps":
+		return s.GenTPS
+	case "prompt_tps":
+		return s.PromptTPS
+	case "total_duration_ms":
+		return s.TotalDurationMs
+	case "load_duration_ms":
+		return s.LoadDurationMs
+	case "chars_per_sec":
+		return s.CharsPerSec
+	case "words_per_sec":
+		return s.WordsPerSec
+	}
+	return nil
+}
+
+// MarkUnavailable records why a metric has no value.
+func (s *Stats) MarkUnavailable(key, reason string) {
+	if s.Unavailable == nil {
+		s.Unavailable = make(map[string]string)
+	}
+	s.Unavailable[key] = reason
+}
+
+// UnavailableReason explains a missing metric, falling back to a generic reason.
+func (s *Stats) UnavailableReason(key string) string {
+	if reason, ok := s.Unavailable[key]; ok {
+		return reason
+	}
+	return "no samples were recorded"
+}
+
+// Statistic returns one aggregate of the metric by JSON key. It reports false for a
+// nil metric, so callers can treat missing data as N/A instead of dereferencing it.
+func (m *StatsMetric) Statistic(key string) (float64, bool) {
+	if m == nil {
+		return 0, false
+	}
+	switch key {
+	case "mean":
+		return m.Mean, true
+	case "median":
+		return m.Median, true
+	case "p99":
+		return m.P99, true
+	}
+	return 0, false
+}
+
+// UseCase returns the suitability stored under a JSON key, or nil if the key is unknown.
+func (r *SuitabilityReport) UseCase(key string) *Suitability {
+	switch key {
+	case "quick_qa":
+		return &r.QuickQA
+	case "coding":
+		return &r.Coding
+	case "writing":
+		return &r.Writing
+	case "summarization":
+		return &r.Summarization
+	case "data_analysis":
+		return &r.DataAnalysis
+	}
+	return nil
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // SystemInfo holds the hardware telemetry data.
 type SystemInfo struct {
 	Arch string `json:"arch"`
@@ -28,6 +30,10 @@ type RAM struct {
 	SpeedMts int    `json:"speed_mts"` // MT/s
 }
 
+// MetricsVersion identifies the layout of the result JSON. Bump it whenever a field
+// is added, removed or changes meaning, and publish a matching schema.
+const MetricsVersion = "1.2"
+
 // BenchmarkResult holds the results of the inference tests.
 type BenchmarkResult struct {
 	MetricsVersion    string        `json:"metrics_version"`
@@ -35,12 +41,14 @@ type BenchmarkResult struct {
 	InitialLoadMs     float64       `json:"initial_load_ms"`      // Load duration of first benchmark iteration
 	SteadyStateLoadMs float64       `json:"steady_state_load_ms"` // Mean load duration of subsequent iterations
 	Benchmarks        Benchmarks    `json:"benchmarks"`
+	TokenDensity      *TokenDensity `json:"token_density,omitempty"` // Measured across all profiles
 }
 
 type ModelMetadata struct {
 	Name         string `json:"name"`
 	Quantization string `json:"quantization"`
 	SizeMB       int    `json:"size_mb"`
+	Digest       string `json:"digest,omitempty"` // Identifies the exact weights, so re-pulled tags can be told apart
 }
 
 type Benchmarks struct {
@@ -52,14 +60,43 @@ type Benchmarks struct {
 }
 
 type ProfileStats struct {
-	Description string `json:"description"`
-	Config      Config `json:"config"`
-	Stats       Stats  `json:"stats"`
+	Description string         `json:"description"`
+	Config      Config         `json:"config"`
+	Stats       Stats          `json:"stats"`
+	Thinking    *ThinkingStats `json:"thinking,omitempty"` // Only for profiles run with think enabled
+	Density     *TokenDensity  `json:"token_density,omitempty"`
+	Energy      *EnergyStats   `json:"energy,omitempty"` // nil when no power source is readable
+	Warnings    []RunWarning   `json:"warnings,omitempty"`
+	RawSamples  []RawSample    `json:"raw_samples,omitempty"` // Only recorded with --raw-samples
+}
+
+// RawSample is one iteration's unaggregated measurements, kept so results can be
+// re-analysed later. Durations are in milliseconds as reported by Ollama, except
+// WallClockMs, which the client measures from sending the request to the last byte.
+type RawSample struct {
+	Iteration            int       `json:"iteration"` // 1-based
+	StartedAt            time.Time `json:"started_at"`
+	WallClockMs          float64   `json:"wall_clock_ms"`
+	TotalDurationMs      float64   `json:"total_duration_ms"`
+	LoadDurationMs       float64   `json:"load_duration_ms"`
+	PromptEvalDurationMs float64   `json:"prompt_eval_duration_ms"`
+	EvalDurationMs       float64   `json:"eval_duration_ms"`
+	PromptEvalCount      int       `json:"prompt_eval_count"`
+	EvalCount            int       `json:"eval_count"`
+	Error                string    `json:"error,omitempty"` // Set when the request failed; the timings are zero
+}
+
+// RunWarning flags iterations whose measurements should not be trusted.
+type RunWarning struct {
+	Kind       string `json:"kind"` // early_eos, context_truncation, degenerate_output, failed_request
+	Message    string `json:"message"`
+	Iterations []int  `json:"iterations"` // 1-based
 }
 
 type Config struct {
-	InputTokens  int `json:"input_tokens"`
-	OutputTokens int `json:"output_tokens"`
+	InputTokens  int  `json:"input_tokens"`
+	OutputTokens int  `json:"output_tokens"`
+	Think        bool `json:"think,omitempty"` // Thinking mode was requested
 }
 
 type Stats struct {
@@ -68,32 +105,163 @@ type Stats struct {
 	PromptTPS       *StatsMetric `json:"prompt_tps,omitempty"`
 	TotalDurationMs *StatsMetric `json:"total_duration_ms,omitempty"`
 	LoadDurationMs  *StatsMetric `json:"load_duration_ms,omitempty"`
+	CharsPerSec     *StatsMetric `json:"chars_per_sec,omitempty"`
+	WordsPerSec     *StatsMetric `json:"words_per_sec,omitempty"`
+
+	// Unavailable explains, by metric key, why a metric has no value (N/A).
+	// A nil metric without an entry here was simply not measured.
+	Unavailable map[string]string `json:"unavailable,omitempty"`
+}
+
+// TokenDensity describes how the model's tokenizer splits its own output text,
+// so throughput can be compared across models with different vocabularies.
+type TokenDensity struct {
+	Tokens        int     `json:"tokens"`
+	Words         int     `json:"words"`
+	Chars         int     `json:"chars"`
+	TokensPerWord float64 `json:"tokens_per_word"`
+	TokensPerChar float64 `json:"tokens_per_char"`
 }
 
 type StatsMetric struct {
-	Mean   float64 `json:"mean"`
-	Median float64 `json:"median"`
-	P99    float64 `json:"p99"`
+	Mean   float64  `json:"mean"`
+	Median float64  `json:"median"`
+	P99    float64  `json:"p99"`
+	StdDev *float64 `json:"stddev"`            // Sample standard deviation; 0 with fewer than 2 samples, null in migrated results that predate it
+	N      int      `json:"samples,omitempty"` // Number of samples; 0 in results recorded before it was tracked
+}
+
+// ThinkingStats splits a thinking model's output into its reasoning phase and the
+// visible answer. Token counts are derived from streamed chunks.
+type ThinkingStats struct {
+	ThinkingTokens      *StatsMetric `json:"thinking_tokens,omitempty"`
+	AnswerTokens        *StatsMetric `json:"answer_tokens,omitempty"`
+	TimeToFirstAnswerMs *StatsMetric `json:"time_to_first_answer_ms,omitempty"` // Client-side, from request start
+	ThinkingTPS         *StatsMetric `json:"thinking_tps,omitempty"`
+	AnswerTPS           *StatsMetric `json:"answer_tps,omitempty"`
+}
+
+// EnergyStats holds the energy drawn by the host while a profile ran.
+type EnergyStats struct {
+	Sources           []string `json:"sources"` // "rapl", "nvidia-smi"
+	DurationMs        float64  `json:"duration_ms"`
+	CPUJoules         float64  `json:"cpu_joules"`
+	GPUJoules         float64  `json:"gpu_joules"`
+	TotalJoules       float64  `json:"total_joules"`
+	AvgWatts          float64  `json:"avg_watts"`
+	GeneratedTokens   int      `json:"generated_tokens"`
+	JoulesPer1kTokens float64  `json:"joules_per_1k_tokens"`
+	TokensPerJoule    float64  `json:"tokens_per_joule"`
 }
 
 // SuitabilityReport holds the analyzed ratings for each use case.
 type SuitabilityReport struct {
-	QuickQA        Suitability `json:"quick_qa"`
-	Coding         Suitability `json:"coding"`
-	Writing        Suitability `json:"writing"`
-	Summarization  Suitability `json:"summarization"`
-	DataAnalysis   Suitability `json:"data_analysis"`
-	OverallVerdict string      `json:"overall_verdict"`
+	QuickQA        Suitability    `json:"quick_qa"`
+	Coding         Suitability    `json:"coding"`
+	Writing        Suitability    `json:"writing"`
+	Summarization  Suitability    `json:"summarization"`
+	DataAnalysis   Suitability    `json:"data_analysis"`
+	OverallVerdict string         `json:"overall_verdict"`
+	Score          *RigRankScore  `json:"rigrank_score,omitempty"`
+	TaskEstimates  []TaskEstimate `json:"task_estimates,omitempty"`
+}
+
+// TaskEstimate is the predicted wall-clock time of an everyday task on this rig.
+type TaskEstimate struct {
+	Key          string  `json:"key"`
+	Description  string  `json:"description"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	FirstTokenMs float64 `json:"first_token_ms"`
+	ReadMs       float64 `json:"read_ms"`  // Prompt ingestion
+	WriteMs      float64 `json:"write_ms"` // Answer generation
+	TotalMs      float64 `json:"total_ms"`
+	// Unavailable explains why the task couldn't be estimated; the times are zero then
+	Unavailable string `json:"unavailable,omitempty"`
+}
+
+// RigRankScore is a single sortable number for the rig + model. 100 means the run
+// matched the reference baseline on every use case; higher is better.
+type RigRankScore struct {
+	FormulaVersion string             `json:"formula_version"`
+	Composite      float64            `json:"composite"`
+	SubScores      map[string]float64 `json:"sub_scores"`        // Keyed by use case
+	Missing        []string           `json:"missing,omitempty"` // Use cases excluded for lack of data
+	Partial        bool               `json:"partial,omitempty"` // The composite covers only some use cases, so it isn't comparable with a full one
 }
 
 type Suitability struct {
-	Rating string `json:"rating"` // EXCELLENT, GOOD, MARGINAL, POOR
+	Rating string `json:"rating"` // EXCELLENT, GOOD, MARGINAL, POOR, INSUFFICIENT_DATA
 	Reason string `json:"reason"`
 }
 
+// ResourceTimeline records host and Ollama resource usage sampled for the whole suite.
+type ResourceTimeline struct {
+	IntervalMs int              `json:"interval_ms"`
+	Samples    []ResourceSample `json:"samples"`
+	Spikes     []ResourceSpike  `json:"spikes,omitempty"` // Iterations that overlapped a spike
+}
+
+type ResourceSample struct {
+	Timestamp        time.Time `json:"timestamp"`
+	CPUPercent       float64   `json:"cpu_percent"` // Whole host, 0-100
+	RAMAvailableMB   uint64    `json:"ram_available_mb"`
+	SwapInBytes      uint64    `json:"swap_in_bytes"`  // Since the previous sample
+	SwapOutBytes     uint64    `json:"swap_out_bytes"` // Since the previous sample
+	OllamaRSSMB      uint64    `json:"ollama_rss_mb"`
+	OllamaCPUPercent float64   `json:"ollama_cpu_percent"` // Per-core percent, may exceed 100
+}
+
+// ResourceSpike marks a benchmark iteration that ran while the host was disturbed.
+type ResourceSpike struct {
+	Profile   string   `json:"profile"`
+	Iteration int      `json:"iteration"` // 1-based
+	Reasons   []string `json:"reasons"`
+}
+
+// IterationWindow is the wall-clock span of a single benchmark request.
+type IterationWindow struct {
+	Profile   string
+	Iteration int // 1-based
+	Start     time.Time
+	End       time.Time
+}
+
 // FullReport is the top-level structure for the JSON output.
 type FullReport struct {
 	SystemInfo         *SystemInfo        `json:"system_info"`
 	InferenceResults   *BenchmarkResult   `json:"inference_results"`
 	UseCaseSuitability *SuitabilityReport `json:"use_case_suitability"`
+	ResourceTimeline   *ResourceTimeline  `json:"resource_timeline,omitempty"`
+	Provenance         *Provenance        `json:"provenance,omitempty"`
+}
+
+// Provenance records where and how a result was produced, so a file collected from
+// a fleet can still be interpreted long after the run.
+type Provenance struct {
+	RunID         string            `json:"run_id"`
+	Timestamp     time.Time         `json:"timestamp"` // When the run started
+	RigRank       BuildInfo         `json:"rigrank"`
+	OllamaVersion string            `json:"ollama_version,omitempty"`
+	Host          HostInfo          `json:"host"`
+	Command       []string          `json:"command,omitempty"` // Command line as invoked
+	Options       map[string]string `json:"options,omitempty"` // Effective value of every flag, defaults included
+}
+
+// BuildInfo identifies the RigRank binary.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// HostInfo describes the operating system the run used.
+type HostInfo struct {
+	Hostname        string `json:"hostname,omitempty"`
+	OS              string `json:"os"`
+	Platform        string `json:"platform,omitempty"` // e.g. "ubuntu", "darwin"
+	PlatformVersion string `json:"platform_version,omitempty"`
+	KernelVersion   string `json:"kernel_version,omitempty"`
+	KernelArch      string `json:"kernel_arch,omitempty"`
 }
@@ -0,0 +1,115 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rohanelukurthy/rig-rank/internal/export"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// Measurement is the InfluxDB measurement every point is written to.
+const Measurement = "rigrank"
+
+// LineProtocolSink writes reports as InfluxDB line protocol, either to a writer or
+// appended to a file that `influx write` or Telegraf can pick up.
+type LineProtocolSink struct {
+	w    io.Writer
+	path string
+}
+
+// NewLineProtocolSink writes to w, or to stdout when w is nil.
+func NewLineProtocolSink(w io.Writer) *LineProtocolSink {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &LineProtocolSink{w: w}
+}
+
+// NewLineProtocolFile appends to the file at path, creating it if needed.
+func NewLineProtocolFile(path string) *LineProtocolSink {
+	return &LineProtocolSink{path: path}
+}
+
+// Stdout reports whether the sink writes to stdout, which it shares with any other
+// output printed there.
+func (s *LineProtocolSink) Stdout() bool {
+	return s.path == "" && s.w == os.Stdout
+}
+
+func (s *LineProtocolSink) Name() string {
+	if s.path != "" {
+		return "influx " + s.path
+	}
+	return "influx"
+}
+
+func (s *LineProtocolSink) Publish(_ context.Context, r *models.FullReport) error {
+	data := LineProtocol(r)
+	if s.path == "" {
+		_, err := s.w.Write(data)
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LineProtocol encodes a report as one point per profile, tagged by host, model,
+// profile and quantization, with a <metric>_<statistic> float field for every
+// recorded statistic and the run ID as a string field. Points carry the run's start
+// time in nanoseconds, or none for reports without provenance.
+func LineProtocol(r *models.FullReport) []byte {
+	var b strings.Builder
+	rows := export.Rows(r, "")
+	for start := 0; start < len(rows); {
+		end := start
+		for end < len(rows) && rows[end].Profile == rows[start].Profile {
+			end++
+		}
+		first := rows[start]
+
+		b.WriteString(escapeMeasurement(Measurement))
+		for _, tag := range [][2]string{{"host", first.Hostname}, {"model", first.Model}, {"profile", first.Profile}, {"quantization", first.Quantization}} {
+			if tag[1] != "" {
+				b.WriteString("," + escapeKey(tag[0]) + "=" + escapeKey(tag[1]))
+			}
+		}
+		for i, row := range rows[start:end] {
+			sep := ","
+			if i == 0 {
+				sep = " "
+			}
+			b.WriteString(sep + escapeKey(row.Metric+"_"+row.Statistic) + "=" + strconv.FormatFloat(row.Value, 'f', -1, 64))
+		}
+		if first.RunID != "" {
+			b.WriteString(`,run_id="` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(first.RunID) + `"`)
+		}
+		if first.Timestamp != nil {
+			fmt.Fprintf(&b, " %d", first.Timestamp.UnixNano())
+		}
+		b.WriteString("\n")
+		start = end
+	}
+	return []byte(b.String())
+}
+
+// escapeMeasurement and escapeKey apply the line protocol escaping rules; tag
+// values follow the same rules as keys.
+func escapeMeasurement(s string) string {
+	return strings.NewReplacer(",", `\,`, " ", `\ `).Replace(s)
+}
+
+func escapeKey(s string) string {
+	return strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `).Replace(s)
+}
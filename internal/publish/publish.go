@@ -0,0 +1,79 @@
+// Package publish pushes finished reports to external systems such as InfluxDB or an
+// internal collector.
+package publish
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+const (
+	SinkInflux  = "influx"
+	SinkWebhook = "webhook"
+)
+
+// Sinks lists the sink kinds by name.
+var Sinks = []string{SinkInflux, SinkWebhook}
+
+// Sink delivers a finished report to one destination.
+type Sink interface {
+	// Name identifies the sink in messages, e.g. "webhook https://collector".
+	Name() string
+	Publish(ctx context.Context, r *models.FullReport) error
+}
+
+// WebhookOptions configure webhook sinks.
+type WebhookOptions struct {
+	Headers   map[string]string
+	Timeout   time.Duration // Per attempt; 0 means 30s
+	Retries   int           // Extra attempts after a network error, 429 or 5xx
+	RetryWait time.Duration // Initial backoff, doubled per attempt; 0 means 1s
+}
+
+// ParseSink builds a sink from a spec: "influx" for line protocol on stdout,
+// "influx=PATH" to append it to a file, or "webhook=URL".
+func ParseSink(spec string, webhook WebhookOptions) (Sink, error) {
+	kind, target, _ := strings.Cut(spec, "=")
+	switch kind {
+	case SinkInflux:
+		if target == "" || target == "-" {
+			return NewLineProtocolSink(nil), nil
+		}
+		return NewLineProtocolFile(target), nil
+	case SinkWebhook:
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("webhook needs an http(s) URL, e.g. webhook=https://collector/rigrank")
+		}
+		return NewWebhook(target, webhook), nil
+	}
+	return nil, fmt.Errorf("unknown sink %q (want one of %s)", kind, strings.Join(Sinks, ", "))
+}
+
+// ParseHeader splits a "Name: value" header.
+func ParseHeader(h string) (name, value string, err error) {
+	name, value, ok := strings.Cut(h, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("invalid header %q (want Name: value)", h)
+	}
+	return name, strings.TrimSpace(value), nil
+}
+
+// Publish sends the report to every sink. A failing sink doesn't stop the others;
+// their errors are joined.
+func Publish(ctx context.Context, r *models.FullReport, sinks []Sink) error {
+	var errs []error
+	for _, s := range sinks {
+		if err := s.Publish(ctx, r); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
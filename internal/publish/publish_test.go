@@ -0,0 +1,173 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/reporttest"
+)
+
+func TestLineProtocol(t *testing.T) {
+	r := reporttest.Report(t)
+	r.Provenance.Host.Hostname = "lab 07" // Spaces in tag values are escaped
+	lines := strings.Split(strings.TrimSpace(string(LineProtocol(r))), "\n")
+	want := []string{
+		`rigrank,host=lab\ 07,model=llama3,profile=atomic,quantization=Q4_0 ttft_ms_mean=100,ttft_ms_median=98,ttft_ms_p99=120,ttft_ms_stddev=1.5,ttft_ms_samples=5,` +
+			`total_duration_ms_mean=250,total_duration_ms_median=245,total_duration_ms_p99=290,total_duration_ms_stddev=12.5,total_duration_ms_samples=5,run_id="abc123" 1772366400000000000`,
+		`rigrank,host=lab\ 07,model=llama3,profile=code_gen,quantization=Q4_0 gen_tps_mean=40.5,gen_tps_median=41,gen_tps_p99=45,` +
+			`total_duration_ms_mean=37500,total_duration_ms_median=37200,total_duration_ms_p99=39100,total_duration_ms_stddev=820,total_duration_ms_samples=3,run_id="abc123" 1772366400000000000`,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("Expected one point per profile with data, got:\n%s", strings.Join(lines, "\n"))
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("Line %d:\n got %s\nwant %s", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestLineProtocolFile_Appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.lp")
+	sink, err := ParseSink("influx="+path, WebhookOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if err := sink.Publish(context.Background(), reporttest.Report(t)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, _ := os.ReadFile(path)
+	if n := bytes.Count(data, []byte("\n")); n != 4 {
+		t.Errorf("Expected two runs of two points, got %d lines", n)
+	}
+}
+
+func TestWebhook_RetriesThenDelivers(t *testing.T) {
+	var attempts atomic.Int32
+	var got models.FullReport
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected headers: %v", r.Header)
+		}
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	sink, err := ParseSink("webhook="+srv.URL, WebhookOptions{
+		Headers:   map[string]string{"Authorization": "Bearer s3cret"},
+		Retries:   3,
+		RetryWait: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Publish(context.Background(), reporttest.Report(t), []Sink{sink}); err != nil {
+		t.Fatalf("Expected delivery after retries, got %v", err)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts.Load())
+	}
+	if got.Provenance == nil || got.Provenance.RunID != "abc123" {
+		t.Errorf("Expected the full report as the body, got %+v", got)
+	}
+}
+
+func TestWebhook_ClientErrorNotRetried(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	sinks := []Sink{NewWebhook(srv.URL, WebhookOptions{Retries: 3, RetryWait: time.Millisecond}), NewLineProtocolSink(&out)}
+	err := Publish(context.Background(), reporttest.Report(t), sinks)
+	if err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Errorf("Expected the 400 to be reported, got %v", err)
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("Expected no retries on a client error, got %d attempts", attempts.Load())
+	}
+	if out.Len() == 0 {
+		t.Error("Expected the other sinks to publish despite the failure")
+	}
+}
+
+func TestWebhook_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	err := NewWebhook(srv.URL, WebhookOptions{Timeout: 20 * time.Millisecond}).Publish(context.Background(), reporttest.Report(t))
+	var netErr interface{ Timeout() bool }
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("Expected a timeout, got %v", err)
+	}
+}
+
+func TestWebhook_RedactsURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	target := srv.URL + "/hooks/s3cr3t?token=s3cr3t"
+	srv.Close() // Refuse the connection so the transport error quotes the URL
+
+	sink := NewWebhook(target, WebhookOptions{})
+	err := Publish(context.Background(), reporttest.Report(t), []Sink{sink})
+	if err == nil {
+		t.Fatal("Expected publishing to a closed server to fail")
+	}
+	for _, msg := range []string{sink.Name(), err.Error()} {
+		if strings.Contains(msg, "s3cr3t") {
+			t.Errorf("Expected the path and query to be redacted, got %q", msg)
+		}
+	}
+	if want := "webhook " + srv.URL; sink.Name() != want {
+		t.Errorf("Expected name %q, got %q", want, sink.Name())
+	}
+}
+
+func TestParseSink(t *testing.T) {
+	for _, spec := range []string{"kafka=broker:9092", "webhook=", "webhook=ftp://example.com"} {
+		if _, err := ParseSink(spec, WebhookOptions{}); err == nil {
+			t.Errorf("Expected %q to be rejected", spec)
+		}
+	}
+	for spec, stdout := range map[string]bool{"influx": true, "influx=-": true, "influx=results.lp": false} {
+		sink, err := ParseSink(spec, WebhookOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := sink.(*LineProtocolSink).Stdout(); got != stdout {
+			t.Errorf("Expected %q to write to stdout: %v, got %v", spec, stdout, got)
+		}
+	}
+	if name, value, err := ParseHeader("X-Team:  inference "); err != nil || name != "X-Team" || value != "inference" {
+		t.Errorf("Unexpected header parse: %q %q %v", name, value, err)
+	}
+	if _, _, err := ParseHeader("no-colon"); err == nil {
+		t.Error("Expected a header without a colon to be rejected")
+	}
+}
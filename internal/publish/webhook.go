@@ -0,0 +1,74 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// Webhook POSTs the report as JSON, retrying network errors, 429s and server errors
+// with exponential backoff.
+type Webhook struct {
+	url  string
+	host string // scheme://host only, for messages: the path or query may hold a token
+	http *resty.Client
+}
+
+// NewWebhook creates a webhook sink for url.
+func NewWebhook(url string, opts WebhookOptions) *Webhook {
+	timeout, wait := opts.Timeout, opts.RetryWait
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	if wait <= 0 {
+		wait = time.Second
+	}
+	client := resty.New().
+		SetTimeout(timeout).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("User-Agent", "rigrank").
+		SetHeaders(opts.Headers).
+		SetRetryCount(opts.Retries).
+		SetRetryWaitTime(wait).
+		SetRetryMaxWaitTime(30 * wait).
+		AddRetryCondition(func(r *resty.Response, err error) bool {
+			return err != nil || r.StatusCode() == http.StatusTooManyRequests || r.StatusCode() >= 500
+		})
+	return &Webhook{url: url, host: redactURL(url), http: client}
+}
+
+func (w *Webhook) Name() string {
+	return "webhook " + w.host
+}
+
+func (w *Webhook) Publish(ctx context.Context, r *models.FullReport) error {
+	resp, err := w.http.R().SetContext(ctx).SetBody(r).Post(w.url)
+	if err != nil {
+		// net/http quotes the full URL in its errors
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = w.host
+		}
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("collector returned status %d after %d attempt(s)", resp.StatusCode(), resp.Request.Attempt)
+	}
+	return nil
+}
+
+// redactURL keeps only the scheme and host of a webhook URL, dropping credentials,
+// path and query.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "(invalid URL)"
+	}
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,159 @@
+package recommend
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rohanelukurthy/rig-rank/internal/benchmark"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+const (
+	FitFullGPU        = "FULL_GPU"
+	FitPartialOffload = "PARTIAL_OFFLOAD"
+	FitNone           = "NO_FIT"
+)
+
+const (
+	// Weights are only part of the footprint; KV cache and runtime buffers add roughly 20%
+	memoryOverheadRatio = 1.2
+	// Apple Silicon lets the GPU wire about three quarters of unified memory by default
+	unifiedGPURatio = 0.75
+	// Layers offloaded to the CPU must share system RAM with the OS and other apps
+	offloadRAMRatio = 0.8
+)
+
+//go:embed catalogue.json
+var catalogueJSON []byte
+
+// CatalogueEntry is a popular Ollama model with the on-disk size of its default quant.
+type CatalogueEntry struct {
+	Name          string `json:"name"`
+	Family        string `json:"family"`
+	ParameterSize string `json:"parameter_size"`
+	Quantization  string `json:"quantization"`
+	SizeMB        int    `json:"size_mb"`
+}
+
+// Catalogue returns the embedded list of popular models.
+func Catalogue() []CatalogueEntry {
+	var entries []CatalogueEntry
+	if err := json.Unmarshal(catalogueJSON, &entries); err != nil {
+		panic(fmt.Sprintf("embedded model catalogue is invalid: %v", err))
+	}
+	return entries
+}
+
+// Budget is the memory available to hold model weights.
+type Budget struct {
+	GPUMB   int  `json:"gpu_mb"`   // Memory the GPU can address
+	TotalMB int  `json:"total_mb"` // GPU plus the system RAM usable for CPU-offloaded layers
+	Unified bool `json:"unified"`  // GPU and CPU share one pool (Apple Silicon)
+}
+
+// BudgetFor derives the memory budget from the host's hardware.
+func BudgetFor(sys *models.SystemInfo) Budget {
+	ramMB := int(sys.RAM.TotalMB)
+	if strings.Contains(sys.GPU.Model, "Apple") {
+		gpu := int(float64(ramMB) * unifiedGPURatio)
+		// Unified memory has no separate pool to offload into, so CPU layers compete
+		// for the same RAM and the total can't exceed what the OS leaves free.
+		return Budget{GPUMB: gpu, TotalMB: int(float64(ramMB) * offloadRAMRatio), Unified: true}
+	}
+	return Budget{
+		GPUMB:   sys.GPU.VRAMTotalMB,
+		TotalMB: sys.GPU.VRAMTotalMB + int(float64(ramMB)*offloadRAMRatio),
+	}
+}
+
+// Candidate is one model with its predicted fit on this host.
+type Candidate struct {
+	Name          string  `json:"name"`
+	ParameterSize string  `json:"parameter_size"`
+	Quantization  string  `json:"quantization"`
+	SizeMB        int     `json:"size_mb"`
+	RequiredMB    int     `json:"required_mb"`
+	Fit           string  `json:"fit"`
+	GPUFraction   float64 `json:"gpu_fraction"` // Share of the model that fits in GPU memory
+	Installed     bool    `json:"installed"`
+}
+
+// Recommend predicts the fit of every installed model and every catalogue model, sorted
+// best first: full GPU before offload before no fit, installed models first within a
+// class, and larger (usually more capable) models first after that.
+func Recommend(sys *models.SystemInfo, installed []benchmark.ModelInfo) []Candidate {
+	budget := BudgetFor(sys)
+	seen := make(map[string]bool)
+	var candidates []Candidate
+
+	for _, m := range installed {
+		seen[m.Name] = true
+		candidates = append(candidates, assess(budget, Candidate{
+			Name:          m.Name,
+			ParameterSize: m.Details.ParameterSize,
+			Quantization:  m.Details.QuantizationLevel,
+			SizeMB:        int(m.Size / (1024 * 1024)),
+			Installed:     true,
+		}))
+	}
+	for _, e := range Catalogue() {
+		if seen[e.Name] {
+			continue
+		}
+		candidates = append(candidates, assess(budget, Candidate{
+			Name:          e.Name,
+			ParameterSize: e.ParameterSize,
+			Quantization:  e.Quantization,
+			SizeMB:        e.SizeMB,
+		}))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if fitRank(a.Fit) != fitRank(b.Fit) {
+			return fitRank(a.Fit) < fitRank(b.Fit)
+		}
+		if a.Installed != b.Installed {
+			return a.Installed
+		}
+		return a.SizeMB > b.SizeMB
+	})
+	return candidates
+}
+
+// assess fills in the memory requirement and fit class of a candidate.
+func assess(budget Budget, c Candidate) Candidate {
+	c.RequiredMB = int(float64(c.SizeMB) * memoryOverheadRatio)
+	if c.RequiredMB <= 0 {
+		c.Fit = FitNone
+		return c
+	}
+
+	c.GPUFraction = float64(budget.GPUMB) / float64(c.RequiredMB)
+	if c.GPUFraction > 1 {
+		c.GPUFraction = 1
+	}
+
+	switch {
+	case c.RequiredMB <= budget.GPUMB:
+		c.Fit = FitFullGPU
+	case c.RequiredMB <= budget.TotalMB:
+		c.Fit = FitPartialOffload
+	default:
+		c.Fit = FitNone
+	}
+	return c
+}
+
+func fitRank(fit string) int {
+	switch fit {
+	case FitFullGPU:
+		return 0
+	case FitPartialOffload:
+		return 1
+	}
+	return 2
+}
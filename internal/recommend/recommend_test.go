@@ -0,0 +1,102 @@
+package recommend
+
+import (
+	"testing"
+
+	"github.com/rohanelukurthy/rig-rank/internal/benchmark"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+func find(t *testing.T, candidates []Candidate, name string) Candidate {
+	t.Helper()
+	for _, c := range candidates {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("candidate %s not found", name)
+	return Candidate{}
+}
+
+func TestRecommend_DiscreteGPU(t *testing.T) {
+	sys := &models.SystemInfo{
+		GPU: models.GPU{Model: "NVIDIA GeForce RTX 4070", VRAMTotalMB: 12288},
+		RAM: models.RAM{TotalMB: 32768},
+	}
+	installed := []benchmark.ModelInfo{
+		{Name: "custom:latest", Size: 2000 * 1024 * 1024, Details: benchmark.ModelDetails{ParameterSize: "3B", QuantizationLevel: "Q4_K_M"}},
+		{Name: "llama3.1:8b", Size: 4900 * 1024 * 1024},
+	}
+
+	candidates := Recommend(sys, installed)
+
+	tests := []struct {
+		name string
+		fit  string
+	}{
+		{"llama3.1:8b", FitFullGPU},        // 5.9GB needed, 12GB VRAM
+		{"qwen2.5:32b", FitPartialOffload}, // 24GB needed, 12GB VRAM + 26GB RAM
+		{"llama3.3:70b", FitNone},          // 51.6GB needed
+		{"custom:latest", FitFullGPU},      // Installed models are assessed too
+	}
+	for _, tt := range tests {
+		if got := find(t, candidates, tt.name).Fit; got != tt.fit {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.fit, got)
+		}
+	}
+
+	// Installed models appear once and sort ahead of catalogue models that fit equally well
+	if !candidates[0].Installed || !candidates[1].Installed {
+		t.Errorf("Expected installed models first, got %s and %s", candidates[0].Name, candidates[1].Name)
+	}
+	if candidates[0].Name != "llama3.1:8b" {
+		t.Errorf("Expected the larger installed model first, got %s", candidates[0].Name)
+	}
+	count := 0
+	for _, c := range candidates {
+		if c.Name == "llama3.1:8b" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected installed catalogue model to be listed once, got %d", count)
+	}
+
+	partial := find(t, candidates, "qwen2.5:32b")
+	if partial.GPUFraction <= 0.4 || partial.GPUFraction >= 0.6 {
+		t.Errorf("Expected about half of qwen2.5:32b on the GPU, got %.2f", partial.GPUFraction)
+	}
+}
+
+func TestRecommend_UnifiedMemory(t *testing.T) {
+	sys := &models.SystemInfo{
+		GPU: models.GPU{Model: "Apple M2"},
+		RAM: models.RAM{TotalMB: 24576},
+	}
+
+	budget := BudgetFor(sys)
+	if !budget.Unified || budget.GPUMB != 18432 {
+		t.Errorf("Expected 18GB unified GPU budget, got %+v", budget)
+	}
+
+	candidates := Recommend(sys, nil)
+	if got := find(t, candidates, "qwen2.5:14b").Fit; got != FitFullGPU {
+		t.Errorf("Expected 14B to fit in unified memory, got %s", got)
+	}
+	if got := find(t, candidates, "gemma2:27b").Fit; got != FitPartialOffload {
+		t.Errorf("Expected 27B to need CPU fallback, got %s", got)
+	}
+	if got := find(t, candidates, "qwen2.5:32b").Fit; got != FitNone {
+		t.Errorf("Expected 32B not to fit, got %s", got)
+	}
+}
+
+func TestRecommend_NoGPU(t *testing.T) {
+	sys := &models.SystemInfo{RAM: models.RAM{TotalMB: 8192}}
+
+	for _, c := range Recommend(sys, nil) {
+		if c.Fit == FitFullGPU {
+			t.Errorf("Expected nothing to fit a GPU on a CPU-only host, got %s", c.Name)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// migration upgrades a results document from one metrics version to the next.
+type migration struct {
+	from, to string
+	apply    func(doc map[string]any)
+}
+
+// migrations chain every older metrics version to the next, oldest first.
+var migrations = []migration{
+	// Added stddev, samples, raw samples, energy, thinking, provenance, ... Only
+	// stddev is required; the rest are optional and stay absent.
+	{from: "1.0", to: "1.1", apply: nullStdDev},
+	// Values a file never recorded may be null, and are written as explicit nulls.
+	{from: "1.1", to: "1.2", apply: func(doc map[string]any) { fillNulls(doc, reflect.TypeOf(models.FullReport{})) }},
+}
+
+// Migrate upgrades a results document to models.MetricsVersion. It returns the
+// upgraded JSON and the version the document started at; a document that is
+// already current is returned unchanged. Files without a metrics_version are
+// treated as the oldest version.
+func Migrate(data []byte) ([]byte, string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber() // Keep integers such as byte counts exact
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, "", fmt.Errorf("invalid results file: %w", err)
+	}
+	results, ok := doc["inference_results"].(map[string]any)
+	if !ok {
+		return nil, "", fmt.Errorf("invalid results file: no inference_results")
+	}
+	from, _ := results["metrics_version"].(string)
+	if from == "" {
+		from = migrations[0].from
+	}
+	if from == models.MetricsVersion {
+		return data, from, nil
+	}
+	if !migratable(from) {
+		return nil, from, fmt.Errorf("unsupported metrics_version %q (this RigRank reads %s)", from, strings.Join(knownVersions(), ", "))
+	}
+
+	version := from
+	for _, m := range migrations {
+		if m.from != version {
+			continue
+		}
+		if m.apply != nil {
+			m.apply(doc)
+		}
+		version = m.to
+	}
+	results["metrics_version"] = version
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, from, err
+	}
+	return out, from, nil
+}
+
+// migratable reports whether a metrics version is older than the current one and
+// can be upgraded.
+func migratable(version string) bool {
+	for _, m := range migrations {
+		if m.from == version {
+			return true
+		}
+	}
+	return false
+}
+
+func knownVersions() []string {
+	var versions []string
+	for _, m := range migrations {
+		versions = append(versions, m.from)
+	}
+	return append(versions, models.MetricsVersion)
+}
+
+// nullStdDev marks the standard deviation of every 1.0 benchmark metric as never
+// recorded. 1.1 itself has no way to say so; the chain always goes on to 1.2, which
+// accepts the null.
+func nullStdDev(doc map[string]any) {
+	results, _ := doc["inference_results"].(map[string]any)
+	benchmarks, _ := results["benchmarks"].(map[string]any)
+	for _, profile := range benchmarks {
+		p, _ := profile.(map[string]any)
+		stats, _ := p["stats"].(map[string]any)
+		for _, metric := range stats {
+			m, ok := metric.(map[string]any)
+			if !ok {
+				continue
+			}
+			if _, present := m["stddev"]; !present {
+				m["stddev"] = nil
+			}
+		}
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// fillNulls walks the document alongside the Go type it decodes into and sets
+// every missing nil-able field to an explicit null.
+func fillNulls(v any, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok || t == timeType {
+			return
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, ok := jsonField(f)
+			if !ok {
+				continue
+			}
+			if value, present := obj[name]; present {
+				fillNulls(value, f.Type)
+			} else if nullable(f.Type) {
+				obj[name] = nil
+			}
+		}
+	case reflect.Slice:
+		items, _ := v.([]any)
+		for _, item := range items {
+			fillNulls(item, t.Elem())
+		}
+	case reflect.Map:
+		obj, _ := v.(map[string]any)
+		for _, value := range obj {
+			fillNulls(value, t.Elem())
+		}
+	}
+}
+
+// jsonField returns a struct field's JSON name and whether it is omitempty. ok is
+// false for fields encoding/json skips.
+func jsonField(f reflect.StructField) (name string, omitempty bool, ok bool) {
+	tag := f.Tag.Get("json")
+	if !f.IsExported() || tag == "-" || tag == "" {
+		return "", false, false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, strings.Contains(opts, "omitempty"), true
+}
+
+// nullable reports whether encoding/json writes the zero value as null, and so
+// also accepts null when reading.
+func nullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/reporttest"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// readV10 loads a results file written by the first release.
+func readV10(t *testing.T) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "report-1.0.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestMigrate_FromV10(t *testing.T) {
+	v10 := readV10(t)
+	out, from, err := Migrate(v10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != "1.0" {
+		t.Errorf("Expected to migrate from 1.0, got %q", from)
+	}
+	if err := Validate(out); err != nil {
+		t.Fatalf("Expected the migrated file to match the current schema, got %v", err)
+	}
+
+	var doc map[string]any
+	json.Unmarshal(out, &doc)
+	results := doc["inference_results"].(map[string]any)
+	if results["metrics_version"] != models.MetricsVersion {
+		t.Errorf("Expected metrics_version %s, got %v", models.MetricsVersion, results["metrics_version"])
+	}
+	ttft := results["benchmarks"].(map[string]any)["atomic"].(map[string]any)["stats"].(map[string]any)["ttft_ms"].(map[string]any)
+	if v, ok := ttft["stddev"]; !ok || v != nil {
+		t.Errorf("Expected an explicit null stddev, got %v (present: %v)", v, ok)
+	}
+	if v, ok := doc["provenance"]; !ok || v != nil {
+		t.Errorf("Expected an explicit null provenance, got %v (present: %v)", v, ok)
+	}
+	// Reading an old file goes through the same upgrade
+	r, err := Parse(v10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.InferenceResults.MetricsVersion != models.MetricsVersion || r.InferenceResults.Benchmarks.Atomic.Stats.TTFTMs.StdDev != nil {
+		t.Errorf("Expected Parse to upgrade the file, got %+v", r.InferenceResults)
+	}
+}
+
+func TestMigrate_LargeIntegers(t *testing.T) {
+	v10 := bytes.Replace(readV10(t), []byte(`"total_mb": 32768`), []byte(`"total_mb": 9007199254740993`), 1)
+	out, _, err := Migrate(v10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(out, []byte("9007199254740993")) {
+		t.Error("Expected large integers to survive the migration exactly")
+	}
+}
+
+// The 1.0 → 1.1 step on its own: 1.1 requires stddev, so every metric gets an
+// explicit null for it, and the optional 1.1 additions stay absent.
+func TestNullStdDev(t *testing.T) {
+	var doc map[string]any
+	if err := json.Unmarshal(readV10(t), &doc); err != nil {
+		t.Fatal(err)
+	}
+	nullStdDev(doc)
+
+	benchmarks := doc["inference_results"].(map[string]any)["benchmarks"].(map[string]any)
+	metrics := 0
+	for name, profile := range benchmarks {
+		for metric, v := range profile.(map[string]any)["stats"].(map[string]any) {
+			m := v.(map[string]any)
+			if sd, ok := m["stddev"]; !ok || sd != nil {
+				t.Errorf("Expected a null stddev on %s/%s, got %v (present: %v)", name, metric, sd, ok)
+			}
+			if _, ok := m["samples"]; ok {
+				t.Errorf("Expected the optional samples to stay absent on %s/%s", name, metric)
+			}
+			metrics++
+		}
+	}
+	if metrics != 20 {
+		t.Errorf("Expected 4 metrics in each of 5 profiles, got %d", metrics)
+	}
+	if _, ok := doc["provenance"]; ok {
+		t.Error("Expected the optional provenance to stay absent")
+	}
+}
+
+func TestMigrate_CurrentUnchanged(t *testing.T) {
+	data, _ := json.Marshal(reporttest.Report(t))
+	out, from, err := Migrate(data)
+	if err != nil || from != models.MetricsVersion || !bytes.Equal(out, data) {
+		t.Errorf("Expected a current file to pass through untouched, got from=%q err=%v", from, err)
+	}
+}
+
+func TestMigrate_UnknownVersion(t *testing.T) {
+	_, _, err := Migrate([]byte(`{"inference_results": {"metrics_version": "7.0"}}`))
+	if err == nil || !strings.Contains(err.Error(), `unsupported metrics_version "7.0"`) {
+		t.Errorf("Expected an unsupported-version error, got %v", err)
+	}
+
+	if err := Validate(readV10(t)); err == nil || !strings.Contains(err.Error(), "rigrank migrate") {
+		t.Errorf("Expected validate to point old files at migrate, got %v", err)
+	}
+}
+
+func TestMigrate_FromV11(t *testing.T) {
+	r := reporttest.Report(t)
+	r.InferenceResults.MetricsVersion = "1.1"
+	r.Provenance = nil
+	spread := 0.8
+	r.InferenceResults.Benchmarks.CodeGen.Stats.GenTPS.StdDev = &spread // 1.1 always wrote it
+	v11, _ := json.Marshal(r)
+	frozen, err := compileSchema("1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, _ := jsonschema.UnmarshalJSON(bytes.NewReader(v11))
+	if err := frozen.Validate(doc); err != nil {
+		t.Fatalf("Expected the frozen 1.1 schema to accept a 1.1 file, got %v", err)
+	}
+
+	out, from, err := Migrate(v11)
+	if err != nil || from != "1.1" {
+		t.Fatalf("Expected to migrate from 1.1, got from=%q err=%v", from, err)
+	}
+	if err := Validate(out); err != nil {
+		t.Fatalf("Expected the migrated file to match the current schema, got %v", err)
+	}
+	var migrated map[string]any
+	json.Unmarshal(out, &migrated)
+	if v, ok := migrated["provenance"]; !ok || v != nil {
+		t.Errorf("Expected an explicit null provenance, got %v (present: %v)", v, ok)
+	}
+}
@@ -0,0 +1,39 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// Load reads a FullReport JSON file written by `rigrank run`.
+func Load(path string) (*models.FullReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results: %w", err)
+	}
+	r, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Parse decodes a FullReport, upgrading files written by older versions, and checks
+// that it holds inference results.
+func Parse(data []byte) (*models.FullReport, error) {
+	data, _, err := Migrate(data)
+	if err != nil {
+		return nil, err
+	}
+	var r models.FullReport
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("invalid results file: %w", err)
+	}
+	if r.InferenceResults == nil {
+		return nil, fmt.Errorf("invalid results file: no inference_results")
+	}
+	return &r, nil
+}
@@ -0,0 +1,175 @@
+package report
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// SchemaBaseURL prefixes the $id of every published schema.
+const SchemaBaseURL = "https://github.com/rohanelukurthy/rig-rank/schema/"
+
+//go:embed schema/*.json
+var schemaFiles embed.FS
+
+// schemaFile names the schema for one metrics version.
+func schemaFile(version string) string {
+	return "schema/report-" + version + ".json"
+}
+
+// Schema returns the JSON Schema for results with the given metrics_version.
+func Schema(version string) ([]byte, error) {
+	data, err := schemaFiles.ReadFile(schemaFile(version))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("no schema for metrics_version %q (want one of %s)", version, strings.Join(SchemaVersions(), ", "))
+	}
+	return data, err
+}
+
+// SchemaVersions lists the metrics versions with a published schema, oldest first.
+func SchemaVersions() []string {
+	entries, _ := schemaFiles.ReadDir("schema")
+	var versions []string
+	for _, e := range entries {
+		versions = append(versions, strings.TrimSuffix(strings.TrimPrefix(e.Name(), "report-"), ".json"))
+	}
+	slices.Sort(versions)
+	return versions
+}
+
+// Issue is one place where a results file breaks its schema.
+type Issue struct {
+	Path    string `json:"path"` // JSON pointer into the file, "" for the whole document
+	Message string `json:"message"`
+}
+
+// ValidationError lists every issue found in a results file.
+type ValidationError struct {
+	Version string  `json:"metrics_version,omitempty"`
+	Issues  []Issue `json:"issues"`
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Issues))
+	for i, is := range e.Issues {
+		path := is.Path
+		if path == "" {
+			path = "(root)"
+		}
+		lines[i] = fmt.Sprintf("%s: %s", path, is.Message)
+	}
+	return fmt.Sprintf("%d schema violation(s):\n  %s", len(e.Issues), strings.Join(lines, "\n  "))
+}
+
+// Validate checks a results file against the current layout; a file at an older
+// metrics_version is pointed at `rigrank migrate`. Schema violations are returned
+// as a *ValidationError.
+func Validate(data []byte) error {
+	return validate(data, true)
+}
+
+// ValidateVersion checks a results file against the schema for the metrics_version
+// it declares, so an older file is held to its own layout rather than the current
+// one.
+func ValidateVersion(data []byte) error {
+	return validate(data, false)
+}
+
+func validate(data []byte, currentOnly bool) error {
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("invalid results file: %w", err)
+	}
+	version, issue := declaredVersion(doc)
+	if issue != nil {
+		return &ValidationError{Issues: []Issue{*issue}}
+	}
+	if currentOnly && migratable(version) {
+		msg := fmt.Sprintf("metrics_version %s is outdated (current is %s); upgrade the file with `rigrank migrate`", version, models.MetricsVersion)
+		return &ValidationError{Version: version, Issues: []Issue{{Path: "/inference_results/metrics_version", Message: msg}}}
+	}
+	schema, err := compileSchema(version)
+	if err != nil {
+		return &ValidationError{Version: version, Issues: []Issue{{Path: "/inference_results/metrics_version", Message: err.Error()}}}
+	}
+
+	err = schema.Validate(doc)
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+	issues := collectIssues(verr, nil)
+	slices.SortStableFunc(issues, func(a, b Issue) int { return strings.Compare(a.Path, b.Path) })
+	return &ValidationError{Version: version, Issues: slices.Compact(issues)}
+}
+
+// declaredVersion finds the file's metrics_version, which selects the schema.
+func declaredVersion(doc any) (string, *Issue) {
+	root, ok := doc.(map[string]any)
+	if !ok {
+		return "", &Issue{Message: "expected a JSON object"}
+	}
+	results, ok := root["inference_results"].(map[string]any)
+	if !ok {
+		return "", &Issue{Path: "/inference_results", Message: "missing or not an object"}
+	}
+	version, ok := results["metrics_version"].(string)
+	if !ok {
+		return "", &Issue{Path: "/inference_results/metrics_version", Message: "missing or not a string"}
+	}
+	return version, nil
+}
+
+func compileSchema(version string) (*jsonschema.Schema, error) {
+	data, err := Schema(version)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	url := SchemaBaseURL + "report-" + version + ".json"
+	c := jsonschema.NewCompiler()
+	c.AssertFormat()
+	if err := c.AddResource(url, doc); err != nil {
+		return nil, err
+	}
+	return c.Compile(url)
+}
+
+var printer = message.NewPrinter(language.English)
+
+// collectIssues flattens the error tree into its leaves. Where a field may also be
+// null, the "want null" alternative is dropped so only the real problem is shown.
+func collectIssues(e *jsonschema.ValidationError, issues []Issue) []Issue {
+	if len(e.Causes) == 0 {
+		if t, ok := e.ErrorKind.(*kind.Type); ok && slices.Equal(t.Want, []string{"null"}) {
+			return issues
+		}
+		return append(issues, Issue{Path: pointer(e.InstanceLocation), Message: e.ErrorKind.LocalizedString(printer)})
+	}
+	for _, c := range e.Causes {
+		issues = collectIssues(c, issues)
+	}
+	return issues
+}
+
+// pointer formats a location as a JSON pointer (RFC 6901).
+func pointer(tokens []string) string {
+	var sb strings.Builder
+	for _, t := range tokens {
+		sb.WriteString("/" + strings.ReplaceAll(strings.ReplaceAll(t, "~", "~0"), "/", "~1"))
+	}
+	return sb.String()
+}
@@ -0,0 +1,194 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/reporttest"
+)
+
+var update = flag.Bool("update", false, "rewrite the schema for the current metrics version from the models")
+
+// generateSchema derives the JSON Schema for FullReport from the models' JSON tags.
+// Fields without omitempty are required, nil-able fields may be null (migrations write
+// null for values older files never recorded), and unknown fields are rejected so a
+// layout change can't slip in without a version bump.
+func generateSchema(version string) ([]byte, error) {
+	g := &schemaGen{defs: make(map[string]any)}
+	root := g.object(reflect.TypeOf(models.FullReport{}))
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["$id"] = SchemaBaseURL + "report-" + version + ".json"
+	root["title"] = "RigRank results, metrics_version " + version
+	root["$defs"] = g.defs
+
+	// Pin the version so the schema is only ever applied to its own layout
+	results := g.defs["BenchmarkResult"].(map[string]any)
+	results["properties"].(map[string]any)["metrics_version"] = map[string]any{"const": version}
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+type schemaGen struct {
+	defs map[string]any
+}
+
+func (g *schemaGen) object(t reflect.Type) map[string]any {
+	props := make(map[string]any)
+	required := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, omitempty, ok := jsonField(f)
+		if !ok {
+			continue
+		}
+		s := g.schema(f.Type)
+		if nullable(f.Type) {
+			s = map[string]any{"anyOf": []any{s, map[string]any{"type": "null"}}}
+		}
+		props[name] = s
+		if !omitempty {
+			required = append(required, name)
+		}
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func (g *schemaGen) schema(t reflect.Type) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem())
+	case reflect.Struct:
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = nil // Reserve the name before recursing
+			g.defs[t.Name()] = g.object(t)
+		}
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	}
+	panic("no schema mapping for " + t.String())
+}
+
+func TestSchemaMatchesModels(t *testing.T) {
+	want, err := generateSchema(models.MetricsVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join("schema", "report-"+models.MetricsVersion+".json")
+	if *update {
+		if err := os.WriteFile(path, want, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := Schema(models.MetricsVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date with the models. If the result layout changed, bump models.MetricsVersion, "+
+			"add a migration, then run: go test ./internal/report -run TestSchemaMatchesModels -update", path)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid, err := json.Marshal(reporttest.Report(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(valid); err != nil {
+		t.Fatalf("Expected a freshly written report to validate, got %v", err)
+	}
+
+	// Break the file in three places at once
+	var doc map[string]any
+	json.Unmarshal(valid, &doc)
+	results := doc["inference_results"].(map[string]any)
+	delete(results["model_metadata"].(map[string]any), "name")
+	codeGen := results["benchmarks"].(map[string]any)["code_gen"].(map[string]any)
+	codeGen["stats"].(map[string]any)["gen_tps"].(map[string]any)["mean"] = "fast"
+	doc["use_case_suitability"] = "great"
+	broken, _ := json.Marshal(doc)
+
+	var verr *ValidationError
+	if !errors.As(Validate(broken), &verr) {
+		t.Fatal("Expected a ValidationError")
+	}
+	paths := make(map[string]bool)
+	for _, is := range verr.Issues {
+		paths[is.Path] = true
+	}
+	for _, want := range []string{
+		"/inference_results/model_metadata",
+		"/inference_results/benchmarks/code_gen/stats/gen_tps/mean",
+		"/use_case_suitability",
+	} {
+		if !paths[want] {
+			t.Errorf("Expected an issue at %s, got %+v", want, verr.Issues)
+		}
+	}
+	if len(verr.Issues) != 3 {
+		t.Errorf("Expected exactly 3 issues, got %+v", verr.Issues)
+	}
+}
+
+func TestValidateVersion(t *testing.T) {
+	for _, version := range knownVersions() {
+		if _, err := Schema(version); err != nil {
+			t.Errorf("Expected a published schema for every readable version, got %v", err)
+		}
+	}
+
+	v10 := readV10(t)
+	if err := ValidateVersion(v10); err != nil {
+		t.Fatalf("Expected a 1.0 file to match the 1.0 schema, got %v", err)
+	}
+	// stddev arrived in 1.1, so a 1.0 file may not carry it
+	withStdDev := bytes.Replace(v10, []byte(`"mean": 95.2,`), []byte(`"mean": 95.2, "stddev": 3.1,`), 1)
+	var verr *ValidationError
+	if !errors.As(ValidateVersion(withStdDev), &verr) || len(verr.Issues) != 1 || verr.Issues[0].Path != "/inference_results/benchmarks/atomic/stats/ttft_ms" {
+		t.Errorf("Expected the 1.0 schema to reject a 1.1 field, got %v", verr)
+	}
+}
+
+func TestValidate_UnknownVersion(t *testing.T) {
+	r := reporttest.Report(t)
+	r.InferenceResults.MetricsVersion = "9.9"
+	data, _ := json.Marshal(r)
+
+	var verr *ValidationError
+	if !errors.As(Validate(data), &verr) || len(verr.Issues) != 1 || !strings.Contains(verr.Issues[0].Message, `no schema for metrics_version "9.9"`) {
+		t.Errorf("Expected an unknown-version issue, got %v", verr)
+	}
+}
@@ -0,0 +1,27 @@
+// Package reporttest provides the sample results file shared by the package tests.
+package reporttest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"testing"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// The file is at the current metrics version: two measured profiles, one metric
+// migrated from before stddev and samples were tracked, and a use case of every
+// rating that fails or skips.
+//
+//go:embed testdata/report.json
+var sample []byte
+
+// Report decodes a fresh copy of the sample, so a test may change it freely.
+func Report(t testing.TB) *models.FullReport {
+	t.Helper()
+	var r models.FullReport
+	if err := json.Unmarshal(sample, &r); err != nil {
+		t.Fatal(err)
+	}
+	return &r
+}
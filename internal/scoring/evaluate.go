@@ -9,89 +9,64 @@ import (
 const (
 	RatingExcellent = "EXCELLENT"
 	RatingGood      = "GOOD"
+	RatingMarginal  = "MARGINAL"
 	RatingPoor      = "POOR"
+	// RatingInsufficientData marks a use case whose metric had no valid samples
+	RatingInsufficientData = "INSUFFICIENT_DATA"
 )
 
+// Evaluate rates the results against the default scoring policy.
 func Evaluate(results *models.BenchmarkResult) *models.SuitabilityReport {
-	report := &models.SuitabilityReport{}
+	return DefaultPolicy().Evaluate(results)
+}
 
-	// 1. Quick Q&A (Atomic Check TTFT)
-	ttft := results.Benchmarks.Atomic.Stats.TTFTMs.Mean
-	if ttft < 50 {
-		report.QuickQA = models.Suitability{Rating: RatingExcellent, Reason: fmt.Sprintf("TTFT of %.1fms is very responsive.", ttft)}
-	} else if ttft < 200 {
-		report.QuickQA = models.Suitability{Rating: RatingGood, Reason: fmt.Sprintf("TTFT of %.1fms is acceptable.", ttft)}
-	} else {
-		report.QuickQA = models.Suitability{Rating: RatingPoor, Reason: fmt.Sprintf("TTFT of %.1fms is sluggish.", ttft)}
+// Evaluate rates each use case covered by the policy and derives the overall verdict.
+func (p *Policy) Evaluate(results *models.BenchmarkResult) *models.SuitabilityReport {
+	report := &models.SuitabilityReport{}
+	// A custom policy may leave use cases out; say so rather than leave them unrated
+	unrated := "The scoring policy has no rule for this use case."
+	if p.Name != "" {
+		unrated = fmt.Sprintf("The %s scoring policy has no rule for this use case.", p.Name)
 	}
-
-	// 2. Coding (Code Gen TPS)
-	codeTPS := results.Benchmarks.CodeGen.Stats.GenTPS.Mean
-	if codeTPS > 40 {
-		report.Coding = models.Suitability{Rating: RatingExcellent, Reason: fmt.Sprintf("Generation speed of %.1f t/s is fluid.", codeTPS)}
-	} else if codeTPS > 20 {
-		report.Coding = models.Suitability{Rating: RatingGood, Reason: fmt.Sprintf("Generation speed of %.1f t/s is usable.", codeTPS)}
-	} else {
-		report.Coding = models.Suitability{Rating: RatingPoor, Reason: fmt.Sprintf("Generation speed of %.1f t/s is too slow.", codeTPS)}
+	for _, key := range models.UseCaseKeys {
+		*report.UseCase(key) = models.Suitability{Rating: RatingInsufficientData, Reason: unrated}
 	}
 
-	// 3. Writing (Story Gen TPS)
-	storyTPS := results.Benchmarks.StoryGen.Stats.GenTPS.Mean
-	if storyTPS > 35 {
-		report.Writing = models.Suitability{Rating: RatingExcellent, Reason: fmt.Sprintf("Speed of %.1f t/s is great for drafting.", storyTPS)}
-	} else if storyTPS > 15 {
-		report.Writing = models.Suitability{Rating: RatingGood, Reason: fmt.Sprintf("Speed of %.1f t/s is okay.", storyTPS)}
-	} else {
-		report.Writing = models.Suitability{Rating: RatingPoor, Reason: fmt.Sprintf("Speed of %.1f t/s is distracting.", storyTPS)}
-	}
+	rated := 0
+	goodCount := 0
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		stats := &results.Benchmarks.Profile(rule.Profile).Stats
+		value, ok := stats.Metric(rule.Metric).Statistic(rule.Statistic)
+		if !ok {
+			*report.UseCase(rule.UseCase) = models.Suitability{
+				Rating: RatingInsufficientData,
+				Reason: fmt.Sprintf("No %s data for %s: %s.", rule.Metric, rule.Profile, stats.UnavailableReason(rule.Metric)),
+			}
+			continue
+		}
 
-	// 4. Summarization (Prompt TPS)
-	summTPS := results.Benchmarks.Summarization.Stats.PromptTPS.Mean
-	if summTPS > 200 {
-		report.Summarization = models.Suitability{Rating: RatingExcellent, Reason: fmt.Sprintf("Ingestion speed of %.1f t/s is fast.", summTPS)}
-	} else if summTPS > 100 {
-		report.Summarization = models.Suitability{Rating: RatingGood, Reason: fmt.Sprintf("Ingestion speed of %.1f t/s is decent.", summTPS)}
-	} else {
-		report.Summarization = models.Suitability{Rating: RatingPoor, Reason: fmt.Sprintf("Ingestion speed of %.1f t/s is slow.", summTPS)}
-	}
+		suitability := rule.rate(value)
+		*report.UseCase(rule.UseCase) = suitability
 
-	// 5. Data Analysis (Reasoning Mean TPS - Balance)
-	// We'll use Gen TPS as the primary bottleneck for reasoning usually, but maybe average of both?
-	// Let's use Gen TPS component since that's the waiting part.
-	reasonTPS := results.Benchmarks.Reasoning.Stats.GenTPS.Mean
-	if reasonTPS > 50 {
-		report.DataAnalysis = models.Suitability{Rating: RatingExcellent, Reason: fmt.Sprintf("Complex gen speed of %.1f t/s is superb.", reasonTPS)}
-	} else if reasonTPS > 25 {
-		report.DataAnalysis = models.Suitability{Rating: RatingGood, Reason: fmt.Sprintf("Complex gen speed of %.1f t/s is good.", reasonTPS)}
-	} else {
-		report.DataAnalysis = models.Suitability{Rating: RatingPoor, Reason: fmt.Sprintf("Complex gen speed of %.1f t/s is low.", reasonTPS)}
+		rated++
+		if suitability.Rating != RatingPoor {
+			goodCount++
+		}
 	}
 
 	// Verdict
-	goodCount := 0
-	if report.QuickQA.Rating != RatingPoor {
-		goodCount++
-	}
-	if report.Coding.Rating != RatingPoor {
-		goodCount++
-	}
-	if report.Writing.Rating != RatingPoor {
-		goodCount++
-	}
-	if report.Summarization.Rating != RatingPoor {
-		goodCount++
-	}
-	if report.DataAnalysis.Rating != RatingPoor {
-		goodCount++
-	}
-
-	if goodCount == 5 {
+	if rated == 0 {
+		report.OverallVerdict = "Not enough valid measurements to judge this model on your hardware."
+	} else if goodCount == rated {
 		report.OverallVerdict = "This model performs well on your hardware for all tested use cases."
-	} else if goodCount >= 3 {
+	} else if goodCount*5 >= rated*3 {
 		report.OverallVerdict = "This model is suitable for most tasks, but may struggle with some heavy workloads."
 	} else {
 		report.OverallVerdict = "This model may be too heavy for your hardware. Consider a smaller quantization or parameter count."
 	}
 
+	report.Score = ComputeScore(results)
+
 	return report
 }
@@ -0,0 +1,154 @@
+package scoring
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+const (
+	DirectionHigherIsBetter = "higher_is_better"
+	DirectionLowerIsBetter  = "lower_is_better"
+)
+
+//go:embed default_policy.json
+var defaultPolicyJSON []byte
+
+// Policy maps benchmark metrics to use-case ratings.
+type Policy struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Rules   []Rule `json:"rules"`
+}
+
+// Rule rates one use case from a single statistic of one profile's metric.
+type Rule struct {
+	UseCase   string `json:"use_case"`  // quick_qa, coding, writing, summarization, data_analysis
+	Profile   string `json:"profile"`   // atomic, code_gen, story_gen, summarization, reasoning
+	Metric    string `json:"metric"`    // ttft_ms, gen_tps, prompt_tps, ...
+	Statistic string `json:"statistic"` // mean, median, p99
+	Direction string `json:"direction"` // higher_is_better, lower_is_better
+	Bands     []Band `json:"bands"`
+}
+
+// Band is one rating step. Bands are checked in order; the first whose threshold the
+// value beats wins. The last band has no threshold and catches everything else.
+type Band struct {
+	Rating    string   `json:"rating"`
+	Threshold *float64 `json:"threshold,omitempty"`
+	Reason    string   `json:"reason"` // "{value}" is replaced with the measured value
+}
+
+// DefaultPolicy returns the embedded policy, whose thresholds follow Plan.md.
+func DefaultPolicy() *Policy {
+	p, err := ParsePolicy(defaultPolicyJSON)
+	if err != nil {
+		panic(fmt.Sprintf("embedded scoring policy is invalid: %v", err))
+	}
+	return p
+}
+
+// LoadPolicy reads and validates a scoring policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scoring policy: %w", err)
+	}
+	p, err := ParsePolicy(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// ParsePolicy decodes and validates a scoring policy.
+func ParsePolicy(data []byte) (*Policy, error) {
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid scoring policy: %w", err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (p *Policy) validate() error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("scoring policy has no rules")
+	}
+	ratings := []string{RatingExcellent, RatingGood, RatingMarginal, RatingPoor}
+	seen := make(map[string]bool)
+
+	for i, r := range p.Rules {
+		where := fmt.Sprintf("rule %d (%s)", i+1, r.UseCase)
+		if !slices.Contains(models.UseCaseKeys, r.UseCase) {
+			return fmt.Errorf("%s: unknown use_case %q (want one of %s)", where, r.UseCase, strings.Join(models.UseCaseKeys, ", "))
+		}
+		if seen[r.UseCase] {
+			return fmt.Errorf("%s: use_case is rated by more than one rule", where)
+		}
+		seen[r.UseCase] = true
+		if !slices.Contains(models.ProfileKeys, r.Profile) {
+			return fmt.Errorf("%s: unknown profile %q (want one of %s)", where, r.Profile, strings.Join(models.ProfileKeys, ", "))
+		}
+		if !slices.Contains(models.MetricKeys, r.Metric) {
+			return fmt.Errorf("%s: unknown metric %q (want one of %s)", where, r.Metric, strings.Join(models.MetricKeys, ", "))
+		}
+		if !slices.Contains(models.StatisticKeys, r.Statistic) {
+			return fmt.Errorf("%s: unknown statistic %q (want one of %s)", where, r.Statistic, strings.Join(models.StatisticKeys, ", "))
+		}
+		if r.Direction != DirectionHigherIsBetter && r.Direction != DirectionLowerIsBetter {
+			return fmt.Errorf("%s: direction must be %q or %q", where, DirectionHigherIsBetter, DirectionLowerIsBetter)
+		}
+		if len(r.Bands) == 0 {
+			return fmt.Errorf("%s: no bands", where)
+		}
+
+		for j, b := range r.Bands {
+			if !slices.Contains(ratings, b.Rating) {
+				return fmt.Errorf("%s: band %d has unknown rating %q", where, j+1, b.Rating)
+			}
+			last := j == len(r.Bands)-1
+			if last && b.Threshold != nil {
+				return fmt.Errorf("%s: the last band must omit threshold so every value gets a rating", where)
+			}
+			if !last && b.Threshold == nil {
+				return fmt.Errorf("%s: band %d needs a threshold", where, j+1)
+			}
+			if j > 0 && !last {
+				prev := *r.Bands[j-1].Threshold
+				if (r.Direction == DirectionHigherIsBetter && *b.Threshold > prev) ||
+					(r.Direction == DirectionLowerIsBetter && *b.Threshold < prev) {
+					return fmt.Errorf("%s: band %d threshold is out of order for %s", where, j+1, r.Direction)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// rate applies a rule to a value.
+func (r *Rule) rate(value float64) models.Suitability {
+	for _, b := range r.Bands {
+		if b.Threshold == nil || r.beats(value, *b.Threshold) {
+			return models.Suitability{
+				Rating: b.Rating,
+				Reason: strings.ReplaceAll(b.Reason, "{value}", fmt.Sprintf("%.1f", value)),
+			}
+		}
+	}
+	return models.Suitability{}
+}
+
+func (r *Rule) beats(value, threshold float64) bool {
+	if r.Direction == DirectionLowerIsBetter {
+		return value < threshold
+	}
+	return value > threshold
+}
@@ -0,0 +1,171 @@
+package scoring
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+func resultsWith(ttft, codeTPS, storyTPS, promptTPS, reasonTPS float64) *models.BenchmarkResult {
+	r := &models.BenchmarkResult{}
+	r.Benchmarks.Atomic.Stats.TTFTMs = &models.StatsMetric{Mean: ttft}
+	r.Benchmarks.CodeGen.Stats.GenTPS = &models.StatsMetric{Mean: codeTPS}
+	r.Benchmarks.StoryGen.Stats.GenTPS = &models.StatsMetric{Mean: storyTPS}
+	r.Benchmarks.Summarization.Stats.PromptTPS = &models.StatsMetric{Mean: promptTPS}
+	r.Benchmarks.Reasoning.Stats.GenTPS = &models.StatsMetric{Mean: reasonTPS}
+	return r
+}
+
+func TestDefaultPolicy_Ratings(t *testing.T) {
+	report := Evaluate(resultsWith(30, 35, 20, 60, 5))
+
+	tests := []struct {
+		name string
+		got  models.Suitability
+		want string
+	}{
+		{"quick_qa", report.QuickQA, RatingExcellent},
+		{"coding", report.Coding, RatingGood},
+		{"writing", report.Writing, RatingMarginal},
+		{"summarization", report.Summarization, RatingPoor},
+		{"data_analysis", report.DataAnalysis, RatingPoor},
+	}
+	for _, tt := range tests {
+		if tt.got.Rating != tt.want {
+			t.Errorf("%s: expected %s, got %s (%s)", tt.name, tt.want, tt.got.Rating, tt.got.Reason)
+		}
+	}
+	if !strings.Contains(report.QuickQA.Reason, "30.0ms") {
+		t.Errorf("Expected reason template to include the value, got %q", report.QuickQA.Reason)
+	}
+}
+
+func TestLoadPolicy_Custom(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "strict.json")
+	policy := `{
+		"name": "strict",
+		"rules": [{
+			"use_case": "quick_qa", "profile": "atomic", "metric": "ttft_ms", "statistic": "p99",
+			"direction": "lower_is_better",
+			"bands": [
+				{"rating": "GOOD", "threshold": 10, "reason": "p99 {value}ms"},
+				{"rating": "POOR", "reason": "p99 {value}ms is over budget"}
+			]
+		}]
+	}`
+	if err := os.WriteFile(path, []byte(policy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := LoadPolicy(path)
+	if err != nil {
+		t.Fatalf("LoadPolicy failed: %v", err)
+	}
+
+	results := resultsWith(5, 0, 0, 0, 0)
+	results.Benchmarks.Atomic.Stats.TTFTMs.P99 = 25
+	report := p.Evaluate(results)
+	if report.QuickQA.Rating != RatingPoor {
+		t.Errorf("Expected POOR from p99, got %s", report.QuickQA.Rating)
+	}
+	if report.Coding.Rating != RatingInsufficientData || !strings.Contains(report.Coding.Reason, "strict scoring policy has no rule") {
+		t.Errorf("Expected use cases without a rule to be marked as such, got %s (%s)", report.Coding.Rating, report.Coding.Reason)
+	}
+}
+
+func TestParsePolicy_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown metric":      `{"rules":[{"use_case":"coding","profile":"code_gen","metric":"speed","statistic":"mean","direction":"higher_is_better","bands":[{"rating":"POOR"}]}]}`,
+		"missing catch-all":   `{"rules":[{"use_case":"coding","profile":"code_gen","metric":"gen_tps","statistic":"mean","direction":"higher_is_better","bands":[{"rating":"GOOD","threshold":10}]}]}`,
+		"thresholds reversed": `{"rules":[{"use_case":"coding","profile":"code_gen","metric":"gen_tps","statistic":"mean","direction":"higher_is_better","bands":[{"rating":"GOOD","threshold":10},{"rating":"MARGINAL","threshold":20},{"rating":"POOR"}]}]}`,
+		"unknown rating":      `{"rules":[{"use_case":"coding","profile":"code_gen","metric":"gen_tps","statistic":"mean","direction":"higher_is_better","bands":[{"rating":"OK"}]}]}`,
+	}
+	for name, policy := range tests {
+		if _, err := ParsePolicy([]byte(policy)); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestComputeScore(t *testing.T) {
+	// Exactly the reference baseline scores 100 everywhere
+	score := ComputeScore(resultsWith(100, 40, 40, 300, 40))
+	if score.Composite != 100 {
+		t.Errorf("Expected composite 100 at the reference, got %v", score.Composite)
+	}
+	if score.FormulaVersion != ScoreFormulaVersion {
+		t.Errorf("Expected formula version %s, got %s", ScoreFormulaVersion, score.FormulaVersion)
+	}
+
+	// Halving latency doubles the quick_qa sub-score
+	score = ComputeScore(resultsWith(50, 40, 40, 300, 40))
+	if score.SubScores["quick_qa"] != 200 {
+		t.Errorf("Expected quick_qa sub-score 200, got %v", score.SubScores["quick_qa"])
+	}
+	if score.Composite <= 100 {
+		t.Errorf("Expected composite above 100 for a faster rig, got %v", score.Composite)
+	}
+
+	// A 0ms TTFT is the fastest possible start, scored at the cap rather than dropped
+	score = ComputeScore(resultsWith(0, 40, 40, 300, 40))
+	if score.SubScores["quick_qa"] != maxSubScore || len(score.Missing) != 0 {
+		t.Errorf("Expected a 0ms TTFT to score %d, got %v (missing %v)", maxSubScore, score.SubScores["quick_qa"], score.Missing)
+	}
+
+	if score.Partial {
+		t.Error("Expected a composite over every use case not to be partial")
+	}
+}
+
+func TestComputeScore_MissingUseCase(t *testing.T) {
+	// Missing metrics are excluded rather than scored as zero, and the composite says so
+	results := resultsWith(100, 40, 40, 300, 40)
+	results.Benchmarks.Summarization.Stats.PromptTPS = nil
+	score := ComputeScore(results)
+	if score.Composite != 100 {
+		t.Errorf("Expected composite 100 with one use case missing, got %v", score.Composite)
+	}
+	if len(score.Missing) != 1 || score.Missing[0] != "summarization" {
+		t.Errorf("Expected summarization to be reported missing, got %v", score.Missing)
+	}
+	if _, ok := score.SubScores["summarization"]; ok {
+		t.Errorf("Expected no sub-score for the missing use case, got %v", score.SubScores)
+	}
+	if !score.Partial {
+		t.Error("Expected a composite without summarization to be flagged partial")
+	}
+
+	// With no data at all there is no composite to flag
+	if empty := ComputeScore(&models.BenchmarkResult{}); empty.Composite != 0 || empty.Partial {
+		t.Errorf("Expected no composite without data, got %+v", empty)
+	}
+}
+
+func TestEvaluate_MissingMetrics(t *testing.T) {
+	results := resultsWith(30, 35, 20, 60, 5)
+	results.Benchmarks.CodeGen.Stats.GenTPS = nil
+	results.Benchmarks.CodeGen.Stats.MarkUnavailable("gen_tps", "eval_duration was zero in every iteration")
+	results.Benchmarks.Summarization.Stats.PromptTPS = nil
+
+	report := Evaluate(results)
+	if report.Coding.Rating != RatingInsufficientData {
+		t.Errorf("Expected INSUFFICIENT_DATA for coding, got %s", report.Coding.Rating)
+	}
+	if !strings.Contains(report.Coding.Reason, "eval_duration was zero") {
+		t.Errorf("Expected the recorded reason, got %q", report.Coding.Reason)
+	}
+	if report.Summarization.Rating != RatingInsufficientData {
+		t.Errorf("Expected INSUFFICIENT_DATA for summarization, got %s", report.Summarization.Rating)
+	}
+
+	empty := Evaluate(&models.BenchmarkResult{})
+	if empty.QuickQA.Rating != RatingInsufficientData {
+		t.Errorf("Expected INSUFFICIENT_DATA with no data at all, got %s", empty.QuickQA.Rating)
+	}
+	if empty.Score.Composite != 0 {
+		t.Errorf("Expected no composite score without data, got %v", empty.Score.Composite)
+	}
+}
@@ -0,0 +1,76 @@
+package scoring
+
+import (
+	"math"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// ScoreFormulaVersion identifies the composite score formula. Bump it whenever the
+// reference values or weights change, so scores from different versions aren't compared.
+const ScoreFormulaVersion = "1"
+
+// scoreComponent normalises one use case against the reference baseline.
+type scoreComponent struct {
+	useCase       string
+	profile       string
+	metric        string
+	reference     float64 // The value that scores exactly 100
+	lowerIsBetter bool
+	weight        float64
+}
+
+// The reference baseline is a mid-range rig running an 8B Q4 model comfortably:
+// 100ms to first token, 40 t/s generation and 300 t/s prompt ingestion.
+// Weights favour the generation-heavy use cases that dominate interactive work.
+var scoreComponents = []scoreComponent{
+	{useCase: "quick_qa", profile: "atomic", metric: "ttft_ms", reference: 100, lowerIsBetter: true, weight: 0.15},
+	{useCase: "coding", profile: "code_gen", metric: "gen_tps", reference: 40, weight: 0.25},
+	{useCase: "writing", profile: "story_gen", metric: "gen_tps", reference: 40, weight: 0.20},
+	{useCase: "summarization", profile: "summarization", metric: "prompt_tps", reference: 300, weight: 0.20},
+	{useCase: "data_analysis", profile: "reasoning", metric: "gen_tps", reference: 40, weight: 0.20},
+}
+
+// maxSubScore caps a single use case so one extreme metric can't dominate the composite.
+const maxSubScore = 1000
+
+// ComputeScore derives the composite RigRank score as the weighted geometric mean of the
+// per-use-case sub-scores. Each sub-score is 100 × (measured / reference), inverted for
+// latency. Use cases without data are excluded and the remaining weights renormalised;
+// such a composite is flagged Partial.
+func ComputeScore(results *models.BenchmarkResult) *models.RigRankScore {
+	score := &models.RigRankScore{
+		FormulaVersion: ScoreFormulaVersion,
+		SubScores:      make(map[string]float64),
+	}
+
+	var logSum, weightSum float64
+	for _, c := range scoreComponents {
+		metric := results.Benchmarks.Profile(c.profile).Stats.Metric(c.metric)
+		// A 0ms latency is the best possible result, but zero throughput has no log
+		if metric == nil || metric.Mean < 0 || (metric.Mean == 0 && !c.lowerIsBetter) {
+			score.Missing = append(score.Missing, c.useCase)
+			continue
+		}
+
+		ratio := metric.Mean / c.reference
+		if c.lowerIsBetter {
+			ratio = c.reference / metric.Mean // +Inf at 0ms, capped below
+		}
+		sub := math.Min(100*ratio, maxSubScore)
+		score.SubScores[c.useCase] = round1(sub)
+
+		logSum += c.weight * math.Log(sub)
+		weightSum += c.weight
+	}
+
+	if weightSum > 0 {
+		score.Composite = round1(math.Exp(logSum / weightSum))
+		score.Partial = len(score.Missing) > 0
+	}
+	return score
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,135 @@
+package scoring
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// Task estimates always take latency from the Atomic profile, where the prompt is tiny,
+// and ingestion speed from the Summarization profile, the only long-prompt workload.
+const (
+	taskTTFTProfile   = "atomic"
+	taskPromptProfile = "summarization"
+)
+
+//go:embed default_tasks.json
+var defaultTasksJSON []byte
+
+// TaskCatalogue lists everyday tasks to express measured speeds as wall-clock times.
+type TaskCatalogue struct {
+	Name  string `json:"name"`
+	Tasks []Task `json:"tasks"`
+}
+
+// Task is a workload of a given prompt and answer size.
+type Task struct {
+	Key               string `json:"key"`
+	Description       string `json:"description"`
+	InputTokens       int    `json:"input_tokens"`
+	OutputTokens      int    `json:"output_tokens"`
+	GenerationProfile string `json:"generation_profile"` // Profile whose gen_tps best matches the answer
+}
+
+// DefaultTasks returns the embedded task catalogue.
+func DefaultTasks() *TaskCatalogue {
+	c, err := ParseTasks(defaultTasksJSON)
+	if err != nil {
+		panic(fmt.Sprintf("embedded task catalogue is invalid: %v", err))
+	}
+	return c
+}
+
+// LoadTasks reads and validates a task catalogue file.
+func LoadTasks(path string) (*TaskCatalogue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task catalogue: %w", err)
+	}
+	c, err := ParseTasks(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// ParseTasks decodes and validates a task catalogue.
+func ParseTasks(data []byte) (*TaskCatalogue, error) {
+	var c TaskCatalogue
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid task catalogue: %w", err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (c *TaskCatalogue) validate() error {
+	if len(c.Tasks) == 0 {
+		return fmt.Errorf("task catalogue has no tasks")
+	}
+	seen := make(map[string]bool)
+	for i, t := range c.Tasks {
+		where := fmt.Sprintf("task %d (%s)", i+1, t.Key)
+		if t.Key == "" {
+			return fmt.Errorf("task %d: key is required", i+1)
+		}
+		if seen[t.Key] {
+			return fmt.Errorf("%s: duplicate key", where)
+		}
+		seen[t.Key] = true
+		if t.InputTokens < 0 || t.OutputTokens < 0 || t.InputTokens+t.OutputTokens == 0 {
+			return fmt.Errorf("%s: input_tokens and output_tokens must be non-negative and not both zero", where)
+		}
+		if !slices.Contains(models.ProfileKeys, t.GenerationProfile) {
+			return fmt.Errorf("%s: unknown generation_profile %q (want one of %s)", where, t.GenerationProfile, strings.Join(models.ProfileKeys, ", "))
+		}
+	}
+	return nil
+}
+
+// Estimate predicts how long each task takes: time to first token, plus reading the
+// prompt at the measured prompt speed, plus writing the answer at the measured
+// generation speed. Tasks whose inputs weren't measured are marked unavailable.
+func (c *TaskCatalogue) Estimate(results *models.BenchmarkResult) []models.TaskEstimate {
+	b := &results.Benchmarks
+	ttft := b.Profile(taskTTFTProfile).Stats.Metric("ttft_ms")
+	promptTPS := b.Profile(taskPromptProfile).Stats.Metric("prompt_tps")
+
+	estimates := make([]models.TaskEstimate, 0, len(c.Tasks))
+	for _, t := range c.Tasks {
+		est := models.TaskEstimate{
+			Key:          t.Key,
+			Description:  t.Description,
+			InputTokens:  t.InputTokens,
+			OutputTokens: t.OutputTokens,
+		}
+		genTPS := b.Profile(t.GenerationProfile).Stats.Metric("gen_tps")
+
+		switch {
+		case ttft == nil || ttft.Mean < 0: // 0ms is a valid, if rounded, time to first token
+			est.Unavailable = fmt.Sprintf("no ttft_ms for %s", taskTTFTProfile)
+		case t.InputTokens > 0 && (promptTPS == nil || promptTPS.Mean <= 0):
+			est.Unavailable = fmt.Sprintf("no prompt_tps for %s", taskPromptProfile)
+		case t.OutputTokens > 0 && (genTPS == nil || genTPS.Mean <= 0):
+			est.Unavailable = fmt.Sprintf("no gen_tps for %s", t.GenerationProfile)
+		default:
+			est.FirstTokenMs = ttft.Mean
+			if t.InputTokens > 0 {
+				est.ReadMs = float64(t.InputTokens) / promptTPS.Mean * 1000
+			}
+			if t.OutputTokens > 0 {
+				est.WriteMs = float64(t.OutputTokens) / genTPS.Mean * 1000
+			}
+			est.TotalMs = est.FirstTokenMs + est.ReadMs + est.WriteMs
+		}
+		estimates = append(estimates, est)
+	}
+	return estimates
+}
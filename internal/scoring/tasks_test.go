@@ -0,0 +1,85 @@
+package scoring
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+func TestTaskCatalogue_Estimate(t *testing.T) {
+	catalogue, err := ParseTasks([]byte(`{
+		"name": "test",
+		"tasks": [
+			{"key": "summary", "description": "Summarise", "input_tokens": 6000, "output_tokens": 400, "generation_profile": "summarization"},
+			{"key": "code", "description": "Write code", "input_tokens": 0, "output_tokens": 3000, "generation_profile": "code_gen"}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseTasks failed: %v", err)
+	}
+
+	results := resultsWith(100, 30, 20, 300, 10)
+	results.Benchmarks.Summarization.Stats.GenTPS = nil
+
+	estimates := catalogue.Estimate(results)
+	if len(estimates) != 2 {
+		t.Fatalf("Expected 2 estimates, got %d", len(estimates))
+	}
+
+	// Summarization has no gen_tps, so the task can't be estimated
+	if !strings.Contains(estimates[0].Unavailable, "gen_tps") || estimates[0].TotalMs != 0 {
+		t.Errorf("Expected summary to be unavailable, got %+v", estimates[0])
+	}
+
+	// 100ms TTFT + 3000 tokens at 30 t/s
+	code := estimates[1]
+	if code.Unavailable != "" || math.Abs(code.TotalMs-100_100) > 0.01 || code.ReadMs != 0 {
+		t.Errorf("Expected 100.1s for code, got %+v", code)
+	}
+
+	results.Benchmarks.Summarization.Stats.GenTPS = &models.StatsMetric{Mean: 40}
+	summary := catalogue.Estimate(results)[0]
+	// 100ms + 6000 tokens at 300 t/s + 400 tokens at 40 t/s
+	if math.Abs(summary.TotalMs-30_100) > 0.01 {
+		t.Errorf("Expected 30.1s for summary, got %+v", summary)
+	}
+
+	// A 0ms TTFT still yields an estimate
+	results.Benchmarks.Atomic.Stats.TTFTMs.Mean = 0
+	if code := catalogue.Estimate(results)[1]; code.Unavailable != "" || math.Abs(code.TotalMs-100_000) > 0.01 {
+		t.Errorf("Expected 100s for code with a 0ms TTFT, got %+v", code)
+	}
+}
+
+func TestParseTasks_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"empty", `{"tasks": []}`, "no tasks"},
+		{"unknown profile", `{"tasks": [{"key": "a", "output_tokens": 10, "generation_profile": "nope"}]}`, "unknown generation_profile"},
+		{"duplicate", `{"tasks": [{"key": "a", "output_tokens": 10, "generation_profile": "atomic"}, {"key": "a", "output_tokens": 10, "generation_profile": "atomic"}]}`, "duplicate key"},
+		{"no work", `{"tasks": [{"key": "a", "generation_profile": "atomic"}]}`, "not both zero"},
+	}
+	for _, tt := range tests {
+		_, err := ParseTasks([]byte(tt.json))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestDefaultTasks(t *testing.T) {
+	results := resultsWith(100, 40, 40, 300, 40)
+	results.Benchmarks.Summarization.Stats.GenTPS = &models.StatsMetric{Mean: 40}
+
+	estimates := DefaultTasks().Estimate(results)
+	for _, e := range estimates {
+		if e.Unavailable != "" || e.TotalMs <= 0 {
+			t.Errorf("Expected every default task to be estimated, got %+v", e)
+		}
+	}
+}
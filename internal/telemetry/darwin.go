@@ -3,6 +3,7 @@
 package telemetry
 
 import (
+	"fmt"
 	"os/exec"
 	"regexp"
 	"strconv"
@@ -89,3 +90,8 @@ func getLinuxNvidiaInfo(g *models.GPU) error {
 	// No-op on darwin (not Linux)
 	return nil
 }
+
+func getLinuxNvidiaPowerDraw() (float64, error) {
+	// No-op on darwin (not Linux)
+	return 0, fmt.Errorf("nvidia-smi power draw is only supported on Linux")
+}
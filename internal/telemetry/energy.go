@@ -0,0 +1,208 @@
+package telemetry
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// DefaultPowercapRoot is where Linux exposes RAPL energy counters.
+const DefaultPowercapRoot = "/sys/class/powercap"
+
+// Only top-level package zones (intel-rapl:0, intel-rapl:1, ...) are summed.
+// Sub-zones (intel-rapl:0:0 core, uncore, dram) are already included in their parent.
+var raplZonePattern = regexp.MustCompile(`^intel-rapl:\d+$`)
+
+// EnergyMeter samples package energy from RAPL and GPU power draw from nvidia-smi
+// between Start and Stop. It is not safe for concurrent measurements.
+type EnergyMeter struct {
+	PowercapRoot string        // Overridable for tests; defaults to DefaultPowercapRoot
+	GPUInterval  time.Duration // How often to poll nvidia-smi for power draw
+
+	// gpuPower returns the summed power draw of all GPUs in watts.
+	gpuPower func() (float64, error)
+
+	start     time.Time
+	raplStart map[string]raplReading
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+	mu        sync.Mutex
+	gpuWatts  []float64
+}
+
+type raplReading struct {
+	energyUJ uint64
+	maxUJ    uint64
+}
+
+// NewEnergyMeter creates a meter reading from the default sysfs root.
+func NewEnergyMeter() *EnergyMeter {
+	return &EnergyMeter{
+		PowercapRoot: DefaultPowercapRoot,
+		GPUInterval:  500 * time.Millisecond,
+		gpuPower:     getLinuxNvidiaPowerDraw,
+	}
+}
+
+// Available reports whether at least one power source can be read.
+func (m *EnergyMeter) Available() bool {
+	if zones, err := m.readRAPL(); err == nil && len(zones) > 0 {
+		return true
+	}
+	if m.gpuPower != nil {
+		if _, err := m.gpuPower(); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Start records the initial RAPL counters and begins polling GPU power.
+func (m *EnergyMeter) Start() error {
+	zones, err := m.readRAPL()
+	if err != nil {
+		zones = nil // RAPL unreadable (no permission, not Intel/AMD); GPU may still work
+	}
+	m.raplStart = zones
+	m.gpuWatts = nil
+	m.start = time.Now()
+	m.stopCh = make(chan struct{})
+
+	if m.gpuPower != nil {
+		if _, err := m.gpuPower(); err == nil {
+			m.wg.Add(1)
+			go m.pollGPU(m.stopCh)
+		}
+	}
+	return nil
+}
+
+// Stop ends the measurement and returns the energy consumed since Start.
+// Token-derived fields are left for the caller to fill in.
+func (m *EnergyMeter) Stop() (*models.EnergyStats, error) {
+	if m.stopCh == nil {
+		return nil, fmt.Errorf("energy meter was not started")
+	}
+	close(m.stopCh)
+	m.wg.Wait()
+	m.stopCh = nil
+	elapsed := time.Since(m.start)
+
+	stats := &models.EnergyStats{DurationMs: float64(elapsed.Milliseconds())}
+
+	if len(m.raplStart) > 0 {
+		end, err := m.readRAPL()
+		if err == nil {
+			var totalUJ uint64
+			for zone, before := range m.raplStart {
+				after, ok := end[zone]
+				if !ok {
+					continue
+				}
+				totalUJ += raplDelta(before, after)
+			}
+			stats.CPUJoules = float64(totalUJ) / 1e6
+			stats.Sources = append(stats.Sources, "rapl")
+		}
+	}
+
+	m.mu.Lock()
+	samples := m.gpuWatts
+	m.mu.Unlock()
+	if len(samples) > 0 {
+		var sum float64
+		for _, w := range samples {
+			sum += w
+		}
+		stats.GPUJoules = sum / float64(len(samples)) * elapsed.Seconds()
+		stats.Sources = append(stats.Sources, "nvidia-smi")
+	}
+
+	if len(stats.Sources) == 0 {
+		return nil, fmt.Errorf("no readable power source")
+	}
+
+	stats.TotalJoules = stats.CPUJoules + stats.GPUJoules
+	if elapsed > 0 {
+		stats.AvgWatts = stats.TotalJoules / elapsed.Seconds()
+	}
+	return stats, nil
+}
+
+func (m *EnergyMeter) pollGPU(stop <-chan struct{}) {
+	defer m.wg.Done()
+	ticker := time.NewTicker(m.GPUInterval)
+	defer ticker.Stop()
+
+	sample := func() {
+		if w, err := m.gpuPower(); err == nil {
+			m.mu.Lock()
+			m.gpuWatts = append(m.gpuWatts, w)
+			m.mu.Unlock()
+		}
+	}
+
+	sample()
+	for {
+		select {
+		case <-stop:
+			sample()
+			return
+		case <-ticker.C:
+			sample()
+		}
+	}
+}
+
+// readRAPL reads the energy counters of every top-level RAPL zone under the powercap root.
+func (m *EnergyMeter) readRAPL() (map[string]raplReading, error) {
+	root := m.PowercapRoot
+	if root == "" {
+		root = DefaultPowercapRoot
+	}
+	entries, err := filepath.Glob(filepath.Join(root, "intel-rapl*"))
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make(map[string]raplReading)
+	for _, dir := range entries {
+		name := filepath.Base(dir)
+		if !raplZonePattern.MatchString(name) {
+			continue
+		}
+		energy, err := readUintFile(filepath.Join(dir, "energy_uj"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read RAPL counter %s: %w", name, err)
+		}
+		maxRange, _ := readUintFile(filepath.Join(dir, "max_energy_range_uj"))
+		zones[name] = raplReading{energyUJ: energy, maxUJ: maxRange}
+	}
+	return zones, nil
+}
+
+// raplDelta returns the energy consumed between two readings, accounting for counter wraparound.
+func raplDelta(before, after raplReading) uint64 {
+	if after.energyUJ >= before.energyUJ {
+		return after.energyUJ - before.energyUJ
+	}
+	if before.maxUJ == 0 {
+		return 0
+	}
+	return before.maxUJ - before.energyUJ + after.energyUJ
+}
+
+func readUintFile(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
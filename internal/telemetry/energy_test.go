@@ -0,0 +1,119 @@
+package telemetry
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writePowercapFixture builds a fake /sys/class/powercap tree. Zone directory names
+// contain colons, which Windows checkouts reject, so the tree is created at test time.
+func writePowercapFixture(t *testing.T, root string, zones map[string]uint64) {
+	t.Helper()
+	for zone, energy := range zones {
+		dir := filepath.Join(root, zone)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		files := map[string]string{
+			"energy_uj":           fmt.Sprint(energy),
+			"max_energy_range_uj": "262143328850",
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// Control-type directory without counters, present on real systems
+	if err := os.MkdirAll(filepath.Join(root, "intel-rapl"), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnergyMeter_RAPLFixture(t *testing.T) {
+	root := t.TempDir()
+	writePowercapFixture(t, root, map[string]uint64{
+		"intel-rapl:0":                1_000_000,
+		"intel-rapl:1":                2_000_000,
+		"intel-rapl:0/intel-rapl:0:0": 500_000, // sub-zone, must not be double counted
+	})
+
+	meter := &EnergyMeter{PowercapRoot: root, GPUInterval: 10 * time.Millisecond}
+	if !meter.Available() {
+		t.Fatal("expected fixture RAPL zones to be available")
+	}
+	if err := meter.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+
+	// Package 0 draws 5 J, package 1 draws 2.5 J, the core sub-zone moves too
+	writePowercapFixture(t, root, map[string]uint64{
+		"intel-rapl:0":                6_000_000,
+		"intel-rapl:1":                4_500_000,
+		"intel-rapl:0/intel-rapl:0:0": 4_000_000,
+	})
+	time.Sleep(20 * time.Millisecond)
+
+	stats, err := meter.Stop()
+	if err != nil {
+		t.Fatalf("Stop() failed: %v", err)
+	}
+	if stats.CPUJoules != 7.5 {
+		t.Errorf("Expected 7.5 J from package zones, got %v", stats.CPUJoules)
+	}
+	if stats.TotalJoules != 7.5 {
+		t.Errorf("Expected TotalJoules 7.5, got %v", stats.TotalJoules)
+	}
+	if stats.AvgWatts <= 0 {
+		t.Errorf("Expected positive AvgWatts, got %v", stats.AvgWatts)
+	}
+	if len(stats.Sources) != 1 || stats.Sources[0] != "rapl" {
+		t.Errorf("Expected sources [rapl], got %v", stats.Sources)
+	}
+}
+
+func TestEnergyMeter_GPUPower(t *testing.T) {
+	meter := &EnergyMeter{
+		PowercapRoot: t.TempDir(), // no RAPL zones
+		GPUInterval:  5 * time.Millisecond,
+		gpuPower:     func() (float64, error) { return 200, nil },
+	}
+	if err := meter.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	stats, err := meter.Stop()
+	if err != nil {
+		t.Fatalf("Stop() failed: %v", err)
+	}
+	if stats.AvgWatts < 199 || stats.AvgWatts > 201 {
+		t.Errorf("Expected ~200 W average, got %v", stats.AvgWatts)
+	}
+	if stats.CPUJoules != 0 {
+		t.Errorf("Expected no CPU energy without RAPL, got %v", stats.CPUJoules)
+	}
+}
+
+func TestEnergyMeter_NoSources(t *testing.T) {
+	meter := &EnergyMeter{PowercapRoot: t.TempDir()}
+	if meter.Available() {
+		t.Fatal("expected no power sources")
+	}
+	if err := meter.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	if _, err := meter.Stop(); err == nil {
+		t.Error("expected error when no power source is readable")
+	}
+}
+
+func TestRAPLDelta_Wraparound(t *testing.T) {
+	before := raplReading{energyUJ: 900, maxUJ: 1000}
+	after := raplReading{energyUJ: 100}
+	if got := raplDelta(before, after); got != 200 {
+		t.Errorf("Expected 200 uJ across wraparound, got %d", got)
+	}
+}
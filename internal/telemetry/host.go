@@ -0,0 +1,26 @@
+package telemetry
+
+import (
+	"os"
+	"runtime"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+// GetHostInfo describes the operating system. It is best-effort: fields gopsutil
+// can't read are left empty rather than failing the run.
+func GetHostInfo() models.HostInfo {
+	info := models.HostInfo{OS: runtime.GOOS}
+	if h, err := host.Info(); err == nil {
+		info.Hostname = h.Hostname
+		info.Platform = h.Platform
+		info.PlatformVersion = h.PlatformVersion
+		info.KernelVersion = h.KernelVersion
+		info.KernelArch = h.KernelArch
+	}
+	if info.Hostname == "" {
+		info.Hostname, _ = os.Hostname()
+	}
+	return info
+}
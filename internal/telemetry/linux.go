@@ -48,3 +48,28 @@ func getLinuxNvidiaInfo(g *models.GPU) error {
 
 	return nil
 }
+
+// getLinuxNvidiaPowerDraw queries nvidia-smi for the current power draw, summed across all GPUs.
+func getLinuxNvidiaPowerDraw() (float64, error) {
+	out, err := exec.Command("nvidia-smi",
+		"--query-gpu=power.draw",
+		"--format=csv,noheader,nounits",
+	).Output()
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	var found bool
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		// power.draw is in watts; "[N/A]" on GPUs that don't report it
+		if v, err := strconv.ParseFloat(strings.TrimSpace(line), 64); err == nil {
+			total += v
+			found = true
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("nvidia-smi: power draw not reported")
+	}
+	return total, nil
+}
@@ -2,9 +2,18 @@
 
 package telemetry
 
-import "github.com/rohanelukurthy/rig-rank/internal/models"
+import (
+	"fmt"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
 
 func getLinuxNvidiaInfo(g *models.GPU) error {
 	// No-op on non-Linux platforms (Windows, FreeBSD, etc.)
 	return nil
 }
+
+func getLinuxNvidiaPowerDraw() (float64, error) {
+	// No-op on non-Linux platforms (Windows, FreeBSD, etc.)
+	return 0, fmt.Errorf("nvidia-smi power draw is only supported on Linux")
+}
@@ -0,0 +1,242 @@
+package telemetry
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// SpikeThresholds decide when a timeline sample counts as a resource spike.
+type SpikeThresholds struct {
+	OtherCPUPercent   float64 // Host CPU not attributable to Ollama, 0-100
+	MinRAMAvailableMB uint64
+	SwapActivity      bool // Any swap in/out counts as a spike
+}
+
+// DefaultSpikeThresholds returns thresholds that flag clearly disturbed iterations.
+func DefaultSpikeThresholds() SpikeThresholds {
+	return SpikeThresholds{
+		OtherCPUPercent:   30.0,
+		MinRAMAvailableMB: 512,
+		SwapActivity:      true,
+	}
+}
+
+// ResourceSampler records CPU, RAM, swap and Ollama process usage at a fixed interval
+// while the benchmark suite runs.
+type ResourceSampler struct {
+	Interval time.Duration
+
+	mu      sync.Mutex
+	samples []models.ResourceSample
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+
+	procs        map[int32]*process.Process // Kept across samples so CPU percent has a baseline
+	lastSwapIn   uint64
+	lastSwapOut  uint64
+	haveLastSwap bool
+}
+
+// NewResourceSampler creates a sampler polling at the given interval.
+func NewResourceSampler(interval time.Duration) *ResourceSampler {
+	return &ResourceSampler{
+		Interval: interval,
+		procs:    make(map[int32]*process.Process),
+	}
+}
+
+// Start begins sampling in the background.
+func (s *ResourceSampler) Start() {
+	s.stopCh = make(chan struct{})
+	s.doneCh = make(chan struct{})
+	go s.loop()
+}
+
+// Stop ends sampling and returns the recorded timeline.
+func (s *ResourceSampler) Stop() *models.ResourceTimeline {
+	if s.stopCh != nil {
+		close(s.stopCh)
+		<-s.doneCh
+		s.stopCh = nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &models.ResourceTimeline{
+		IntervalMs: int(s.Interval.Milliseconds()),
+		Samples:    s.samples,
+	}
+}
+
+func (s *ResourceSampler) loop() {
+	defer close(s.doneCh)
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		default:
+		}
+
+		// cpu.Percent blocks for the interval, which doubles as the sampling clock
+		sample, err := s.sample()
+		if err != nil {
+			continue
+		}
+		s.mu.Lock()
+		s.samples = append(s.samples, sample)
+		s.mu.Unlock()
+	}
+}
+
+func (s *ResourceSampler) sample() (models.ResourceSample, error) {
+	cpuPercents, err := cpu.Percent(s.Interval, false)
+	if err != nil {
+		time.Sleep(s.Interval)
+		return models.ResourceSample{}, err
+	}
+
+	sample := models.ResourceSample{Timestamp: time.Now()}
+	if len(cpuPercents) > 0 {
+		sample.CPUPercent = cpuPercents[0]
+	}
+
+	if v, err := mem.VirtualMemory(); err == nil {
+		sample.RAMAvailableMB = v.Available / 1024 / 1024
+	}
+
+	if sw, err := mem.SwapMemory(); err == nil {
+		if s.haveLastSwap {
+			sample.SwapInBytes = counterDelta(s.lastSwapIn, sw.Sin)
+			sample.SwapOutBytes = counterDelta(s.lastSwapOut, sw.Sout)
+		}
+		s.lastSwapIn, s.lastSwapOut, s.haveLastSwap = sw.Sin, sw.Sout, true
+	}
+
+	sample.OllamaRSSMB, sample.OllamaCPUPercent = s.sampleOllama()
+	return sample, nil
+}
+
+// sampleOllama sums RSS and CPU of the Ollama runner processes. The model is served by a
+// child process ("ollama runner" or "ollama_llama_server" on older releases); the parent
+// server is only used when no runner is alive yet.
+func (s *ResourceSampler) sampleOllama() (uint64, float64) {
+	procs, err := process.Processes()
+	if err != nil {
+		return 0, 0
+	}
+
+	var runners, servers []*process.Process
+	seen := make(map[int32]bool)
+	for _, p := range procs {
+		name, err := p.Name()
+		if err != nil || !strings.HasPrefix(strings.ToLower(name), "ollama") {
+			continue
+		}
+		// Reuse the tracked handle so Percent(0) measures since the previous sample
+		tracked, ok := s.procs[p.Pid]
+		if !ok {
+			tracked = p
+			s.procs[p.Pid] = p
+		}
+		seen[p.Pid] = true
+
+		cmdline, _ := p.Cmdline()
+		if strings.Contains(name, "llama_server") || strings.Contains(cmdline, " runner") {
+			runners = append(runners, tracked)
+		} else {
+			servers = append(servers, tracked)
+		}
+	}
+	for pid := range s.procs {
+		if !seen[pid] {
+			delete(s.procs, pid)
+		}
+	}
+
+	targets := runners
+	if len(targets) == 0 {
+		targets = servers
+	}
+
+	var rssMB uint64
+	var cpuPercent float64
+	for _, p := range targets {
+		if mi, err := p.MemoryInfo(); err == nil {
+			rssMB += mi.RSS / 1024 / 1024
+		}
+		if pct, err := p.Percent(0); err == nil {
+			cpuPercent += pct
+		}
+	}
+	return rssMB, cpuPercent
+}
+
+// DetectSpikes marks every iteration whose wall-clock window overlaps a sample that breaches
+// the thresholds. A sample covers the interval that ended at its timestamp.
+func DetectSpikes(timeline *models.ResourceTimeline, windows []models.IterationWindow, th SpikeThresholds) []models.ResourceSpike {
+	if timeline == nil {
+		return nil
+	}
+	interval := time.Duration(timeline.IntervalMs) * time.Millisecond
+	numCPU := float64(runtime.NumCPU())
+
+	var spikes []models.ResourceSpike
+	for _, w := range windows {
+		// One reason per kind; the first breaching sample describes it
+		seen := make(map[string]bool)
+		var ordered []string
+		for _, sample := range timeline.Samples {
+			sampleStart := sample.Timestamp.Add(-interval)
+			if sample.Timestamp.Before(w.Start) || sampleStart.After(w.End) {
+				continue
+			}
+			for _, r := range spikeReasons(sample, th, numCPU) {
+				if !seen[r.kind] {
+					seen[r.kind] = true
+					ordered = append(ordered, r.message)
+				}
+			}
+		}
+		if len(ordered) > 0 {
+			spikes = append(spikes, models.ResourceSpike{Profile: w.Profile, Iteration: w.Iteration, Reasons: ordered})
+		}
+	}
+	return spikes
+}
+
+type spikeReason struct {
+	kind    string
+	message string
+}
+
+func spikeReasons(sample models.ResourceSample, th SpikeThresholds, numCPU float64) []spikeReason {
+	var reasons []spikeReason
+
+	// Ollama's CPU percent is per-core; convert it to a share of the whole host
+	otherCPU := sample.CPUPercent - sample.OllamaCPUPercent/numCPU
+	if th.OtherCPUPercent > 0 && otherCPU > th.OtherCPUPercent {
+		reasons = append(reasons, spikeReason{"cpu", fmt.Sprintf("non-Ollama CPU at %.0f%%", otherCPU)})
+	}
+	if th.MinRAMAvailableMB > 0 && sample.RAMAvailableMB < th.MinRAMAvailableMB {
+		reasons = append(reasons, spikeReason{"ram", fmt.Sprintf("available RAM fell to %d MB", sample.RAMAvailableMB)})
+	}
+	if th.SwapActivity && (sample.SwapInBytes > 0 || sample.SwapOutBytes > 0) {
+		reasons = append(reasons, spikeReason{"swap", "swap activity"})
+	}
+	return reasons
+}
+
+func counterDelta(before, after uint64) uint64 {
+	if after < before {
+		return 0 // Counter reset
+	}
+	return after - before
+}
@@ -0,0 +1,51 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+func TestDetectSpikes(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	timeline := &models.ResourceTimeline{
+		IntervalMs: 1000,
+		Samples: []models.ResourceSample{
+			{Timestamp: base.Add(1 * time.Second), CPUPercent: 5, RAMAvailableMB: 8000},
+			{Timestamp: base.Add(2 * time.Second), CPUPercent: 95, RAMAvailableMB: 8000},
+			{Timestamp: base.Add(3 * time.Second), CPUPercent: 5, RAMAvailableMB: 8000, SwapOutBytes: 4096},
+			{Timestamp: base.Add(4 * time.Second), CPUPercent: 5, RAMAvailableMB: 8000},
+		},
+	}
+	windows := []models.IterationWindow{
+		{Profile: "Atomic Check", Iteration: 1, Start: base.Add(100 * time.Millisecond), End: base.Add(900 * time.Millisecond)},
+		{Profile: "Atomic Check", Iteration: 2, Start: base.Add(1500 * time.Millisecond), End: base.Add(2500 * time.Millisecond)},
+		{Profile: "Atomic Check", Iteration: 3, Start: base.Add(3100 * time.Millisecond), End: base.Add(3900 * time.Millisecond)},
+	}
+
+	spikes := DetectSpikes(timeline, windows, DefaultSpikeThresholds())
+	if len(spikes) != 1 {
+		t.Fatalf("Expected 1 spiked iteration, got %d: %+v", len(spikes), spikes)
+	}
+	if spikes[0].Iteration != 2 {
+		t.Errorf("Expected iteration 2 to be flagged, got %d", spikes[0].Iteration)
+	}
+	if len(spikes[0].Reasons) != 2 {
+		t.Errorf("Expected CPU and swap reasons, got %v", spikes[0].Reasons)
+	}
+}
+
+func TestResourceSampler(t *testing.T) {
+	sampler := NewResourceSampler(100 * time.Millisecond)
+	sampler.Start()
+	time.Sleep(350 * time.Millisecond)
+	timeline := sampler.Stop()
+
+	if len(timeline.Samples) == 0 {
+		t.Fatal("Expected at least one sample")
+	}
+	if timeline.Samples[0].RAMAvailableMB == 0 {
+		t.Error("Expected available RAM to be recorded")
+	}
+}
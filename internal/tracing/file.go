@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// NewFileExporter appends each batch of spans to the file at path, creating it if
+// needed, as one line of OTLP/JSON: the layout the OpenTelemetry Collector's file
+// exporter writes and its otlpjsonfile receiver reads back, so a run recorded
+// offline can be replayed into a backend.
+func NewFileExporter(ctx context.Context, path string) (*otlptrace.Exporter, error) {
+	return otlptrace.New(ctx, &fileClient{path: path})
+}
+
+// fileClient is the otlptrace transport behind NewFileExporter. The exporter converts
+// the spans to their protobuf form; the client only encodes and writes them.
+type fileClient struct {
+	path string
+	mu   sync.Mutex
+	f    *os.File
+}
+
+func (c *fileClient) Start(context.Context) error {
+	f, err := os.OpenFile(c.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open trace file: %w", err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.f = f
+	return nil
+}
+
+func (c *fileClient) Stop(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.f == nil {
+		return nil
+	}
+	err := c.f.Close()
+	c.f = nil
+	return err
+}
+
+func (c *fileClient) UploadTraces(_ context.Context, spans []*tracepb.ResourceSpans) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	line, err := marshalJSON(&tracepb.TracesData{ResourceSpans: spans})
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.f == nil {
+		return fmt.Errorf("trace file is closed")
+	}
+	_, err = c.f.Write(append(line, '\n'))
+	return err
+}
+
+// marshalJSON encodes traces as OTLP/JSON. That is the protobuf JSON mapping with
+// two exceptions: enums are numbers, and trace and span IDs are hex rather than
+// base64, so the IDs are rewritten after protojson has run.
+func marshalJSON(traces *tracepb.TracesData) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(traces)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if err := hexIDs(doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// idFields are the OTLP fields holding trace or span IDs, in spans and their links.
+var idFields = map[string]bool{"traceId": true, "spanId": true, "parentSpanId": true}
+
+func hexIDs(v any) error {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, value := range v {
+			if s, ok := value.(string); ok && idFields[k] {
+				id, err := base64.StdEncoding.DecodeString(s)
+				if err != nil {
+					return fmt.Errorf("invalid %s %q: %w", k, s, err)
+				}
+				v[k] = hex.EncodeToString(id)
+				continue
+			}
+			if err := hexIDs(value); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if err := hexIDs(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestFileExporter_OTLPJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	exp, err := NewFileExporter(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	tr := provider.Tracer("test")
+
+	ctx, parent := tr.Start(context.Background(), "generate")
+	parent.SetAttributes(attribute.String("rigrank.profile", "atomic"), attribute.Int("gen_ai.usage.output_tokens", 42))
+	_, child := tr.Start(ctx, "eval")
+	child.RecordError(errors.New("boom"))
+	child.SetStatus(codes.Error, "boom")
+	child.End()
+	parent.End()
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var spans []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var doc struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Scope struct{ Name string }
+					Spans []map[string]any
+				}
+			}
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &doc); err != nil {
+			t.Fatalf("Expected one JSON document per line: %v", err)
+		}
+		for _, rs := range doc.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				if ss.Scope.Name != "test" {
+					t.Errorf("Unexpected scope %q", ss.Scope.Name)
+				}
+				spans = append(spans, ss.Spans...)
+			}
+		}
+	}
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+	eval, gen := spans[0], spans[1]
+
+	if id, _ := gen["spanId"].(string); len(id) != 16 || eval["parentSpanId"] != id {
+		t.Errorf("Expected hex IDs with eval parented to generate, got %v / %v", gen["spanId"], eval["parentSpanId"])
+	}
+	if id, _ := gen["traceId"].(string); len(id) != 32 || eval["traceId"] != id {
+		t.Errorf("Expected a shared hex trace ID, got %v / %v", gen["traceId"], eval["traceId"])
+	}
+	if _, ok := gen["startTimeUnixNano"].(string); !ok {
+		t.Errorf("Expected timestamps as strings, got %T", gen["startTimeUnixNano"])
+	}
+	attrs, _ := json.Marshal(gen["attributes"])
+	if want := `[{"key":"rigrank.profile","value":{"stringValue":"atomic"}},{"key":"gen_ai.usage.output_tokens","value":{"intValue":"42"}}]`; string(attrs) != want {
+		t.Errorf("Attributes:\n got %s\nwant %s", attrs, want)
+	}
+	if status, _ := json.Marshal(eval["status"]); string(status) != `{"code":2,"message":"boom"}` {
+		t.Errorf("Expected the OTLP error status, got %s", status)
+	}
+	if events, _ := eval["events"].([]any); len(events) != 1 {
+		t.Errorf("Expected the recorded error as an event, got %v", eval["events"])
+	}
+	if status, _ := gen["status"].(map[string]any); status["code"] != nil {
+		t.Errorf("Expected no status code on an unset span, got %v", gen["status"])
+	}
+	if kind, _ := gen["kind"].(float64); kind != 1 {
+		t.Errorf("Expected the span kind as an OTLP enum number, got %v", gen["kind"])
+	}
+}
+
+func TestSetup_RejectsBadEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"localhost:4318", "ftp://collector"} {
+		if _, err := Setup(context.Background(), Options{Endpoint: endpoint}); err == nil {
+			t.Errorf("Expected %q to be rejected", endpoint)
+		}
+	}
+}
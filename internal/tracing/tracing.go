@@ -0,0 +1,100 @@
+// Package tracing exports the benchmark's OpenTelemetry spans to an OTLP endpoint or
+// an OTLP-JSON file, so slow iterations can be inspected in Jaeger or Tempo.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ServiceName identifies RigRank in trace backends.
+const ServiceName = "rigrank"
+
+// Options choose where spans go. Both destinations may be used at once.
+type Options struct {
+	// Endpoint is an OTLP/HTTP receiver such as http://localhost:4318. /v1/traces is
+	// added when the URL has no path. When empty, the standard
+	// OTEL_EXPORTER_OTLP_ENDPOINT variables are honoured if set.
+	Endpoint string
+	File     string // Append OTLP-JSON lines to this file
+	Version  string // RigRank version, recorded as service.version
+	Hostname string
+}
+
+// Enabled reports whether the options, or the environment, ask for any export.
+func (o Options) Enabled() bool {
+	return o.Endpoint != "" || o.File != "" || envEndpoint()
+}
+
+func envEndpoint() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != ""
+}
+
+// Setup installs a global tracer provider exporting to the configured
+// destinations. The returned function flushes and stops it; call it once the run
+// is over. When nothing is configured, Setup leaves the no-op provider in place.
+func Setup(ctx context.Context, opts Options) (shutdown func(context.Context) error, err error) {
+	noop := func(context.Context) error { return nil }
+	if !opts.Enabled() {
+		return noop, nil
+	}
+
+	var exporters []sdktrace.SpanExporter
+	if opts.Endpoint != "" || envEndpoint() {
+		var httpOpts []otlptracehttp.Option
+		if opts.Endpoint != "" {
+			u, err := url.Parse(opts.Endpoint)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return noop, fmt.Errorf("invalid trace endpoint %q (want e.g. http://localhost:4318)", opts.Endpoint)
+			}
+			if u.Path == "" || u.Path == "/" {
+				u.Path = "/v1/traces"
+			}
+			httpOpts = append(httpOpts, otlptracehttp.WithEndpointURL(u.String()))
+		}
+		exp, err := otlptracehttp.New(ctx, httpOpts...)
+		if err != nil {
+			return noop, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+		exporters = append(exporters, exp)
+	}
+	if opts.File != "" {
+		exp, err := NewFileExporter(ctx, opts.File)
+		if err != nil {
+			return noop, err
+		}
+		exporters = append(exporters, exp)
+	}
+
+	attrs := []attribute.KeyValue{attribute.String("service.name", ServiceName)}
+	if opts.Version != "" {
+		attrs = append(attrs, attribute.String("service.version", opts.Version))
+	}
+	if opts.Hostname != "" {
+		attrs = append(attrs, attribute.String("host.name", opts.Hostname))
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attrs...))
+	if err != nil {
+		return noop, err
+	}
+
+	providerOpts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	for _, exp := range exporters {
+		providerOpts = append(providerOpts, sdktrace.WithBatcher(exp))
+	}
+	provider := sdktrace.NewTracerProvider(providerOpts...)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		return errors.Join(provider.ForceFlush(ctx), provider.Shutdown(ctx))
+	}, nil
+}
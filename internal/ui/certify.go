@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rohanelukurthy/rig-rank/internal/certify"
+)
+
+// RenderCertification renders a qualification report with per-requirement pass/fail.
+func RenderCertification(r *certify.Report) string {
+	s := strings.Builder{}
+
+	title := lipgloss.NewStyle().Foreground(colorTitle).Bold(true).Render("📋 Hardware Qualification: " + r.Policy)
+	s.WriteString("\n  " + title + "\n")
+	info := fmt.Sprintf("  policy %s (sha256 %.12s)  |  hardware %s  |  %s", r.PolicyVersion, r.PolicyDigest, r.Fingerprint, r.Timestamp.Format("2006-01-02 15:04 MST"))
+	s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render(info) + "\n\n")
+
+	for _, m := range r.Models {
+		if m.Error != "" {
+			s.WriteString(lipgloss.NewStyle().Foreground(colorPoor).Render(fmt.Sprintf("  ✗ %s could not be benchmarked: %s", m.Name, m.Error)) + "\n")
+		}
+	}
+
+	header := fmt.Sprintf("  %-14s %-20s %-40s %-16s %s", "Requirement", "Model", "Expected", "Actual", "Result")
+	s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render(header) + "\n")
+	for _, req := range r.Requirements {
+		row := fmt.Sprintf("  %-14s %-20s %-40s %-16s ", req.ID, req.Model, req.Expected, req.Actual)
+		result := lipgloss.NewStyle().Foreground(colorExcellent).Render("✓ pass")
+		if !req.Passed {
+			result = lipgloss.NewStyle().Foreground(colorPoor).Render("✗ fail")
+		}
+		s.WriteString(row + result + "\n")
+	}
+
+	if r.Qualified {
+		s.WriteString("\n  " + lipgloss.NewStyle().Foreground(colorExcellent).Bold(true).Render("QUALIFIED: this machine meets every requirement.") + "\n")
+	} else {
+		s.WriteString("\n  " + lipgloss.NewStyle().Foreground(colorPoor).Bold(true).Render("NOT QUALIFIED: one or more requirements failed.") + "\n")
+	}
+
+	if so := r.SignOff; so != nil {
+		line := fmt.Sprintf("  Sealed %s (%s, digest %.12s)", so.SignedAt.Format("2006-01-02 15:04 MST"), so.Algorithm, so.Digest)
+		if so.SignedBy != "" {
+			line += " — signed off by " + so.SignedBy
+		}
+		s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render(line) + "\n")
+	}
+	return s.String()
+}
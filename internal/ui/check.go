@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rohanelukurthy/rig-rank/internal/compare"
+)
+
+// RenderCheck renders the regression gate's per-metric decisions and verdict.
+func RenderCheck(r *compare.CheckResult) string {
+	s := strings.Builder{}
+
+	title := lipgloss.NewStyle().Foreground(colorTitle).Bold(true).Render("🚦 RigRank Regression Check")
+	s.WriteString("\n  " + title + "\n")
+	s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render("  baseline: "+r.Baseline) + "\n\n")
+
+	for _, n := range r.Notes {
+		s.WriteString(lipgloss.NewStyle().Foreground(colorGood).Render("  ⚠️  "+n) + "\n")
+	}
+	if len(r.Notes) > 0 {
+		s.WriteString("\n")
+	}
+
+	header := fmt.Sprintf("  %-15s %-12s %10s %10s %9s %10s  %s", "Profile", "Metric", "Baseline", "Current", "Delta %", "Tolerance", "Status")
+	s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render(header) + "\n")
+
+	for _, c := range r.Checks {
+		row := fmt.Sprintf("  %-15s %-12s %10s %10s %9s %10s  ", profileDisplayName(c.Profile), c.Metric,
+			formatMean(c.Base), formatMean(c.Head), formatDeltaPct(c.MetricDiff), fmt.Sprintf("%.0f%%", c.TolerancePct))
+		status := "✓ pass"
+		color := colorExcellent
+		switch c.Status {
+		case compare.CheckRegressed:
+			status, color = "✗ regressed", colorPoor
+		case compare.CheckMissing:
+			status, color = "✗ missing", colorPoor
+		}
+		s.WriteString(row + lipgloss.NewStyle().Foreground(color).Render(status) + "\n")
+	}
+
+	if r.Passed {
+		s.WriteString("\n  " + lipgloss.NewStyle().Foreground(colorExcellent).Bold(true).Render("PASS: no metric regressed beyond its tolerance.") + "\n")
+	} else {
+		s.WriteString("\n  " + lipgloss.NewStyle().Foreground(colorPoor).Bold(true).Render(fmt.Sprintf("FAIL: %d metric(s) regressed beyond tolerance.", r.Failures)) + "\n")
+	}
+	return s.String()
+}
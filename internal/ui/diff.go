@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rohanelukurthy/rig-rank/internal/compare"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// profileDisplayNames maps profile JSON keys to the short names used in the report card.
+var profileDisplayNames = map[string]string{
+	"atomic":        "Atomic Check",
+	"code_gen":      "Code Gen",
+	"story_gen":     "Story Gen",
+	"summarization": "Summarization",
+	"reasoning":     "Reasoning",
+}
+
+func profileDisplayName(key string) string {
+	if name, ok := profileDisplayNames[key]; ok {
+		return name
+	}
+	return key
+}
+
+// RenderDiff renders a comparison of two runs for the terminal.
+func RenderDiff(d *compare.Diff) string {
+	s := strings.Builder{}
+
+	title := lipgloss.NewStyle().Foreground(colorTitle).Bold(true).Render("🔍 RigRank Diff")
+	s.WriteString("\n  " + title + "\n")
+	s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render(fmt.Sprintf("  base: %s\n  head: %s", d.Base, d.Head)) + "\n\n")
+
+	for _, n := range d.Notes {
+		s.WriteString(lipgloss.NewStyle().Foreground(colorGood).Render("  ⚠️  "+n) + "\n")
+	}
+	if len(d.Notes) > 0 {
+		s.WriteString("\n")
+	}
+
+	header := fmt.Sprintf("  %-15s %-18s %12s %12s %12s %9s  %s", "Profile", "Metric", "Base", "Head", "Delta", "Delta %", "Significance")
+	s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render(header) + "\n")
+
+	for _, m := range d.Metrics {
+		row := fmt.Sprintf("  %-15s %-18s %12s %12s ", profileDisplayName(m.Profile), m.Metric, formatMean(m.Base), formatMean(m.Head))
+		change := fmt.Sprintf("%12s %9s  %s", formatDelta(m), formatDeltaPct(m), significanceLabel(m))
+		s.WriteString(row + lipgloss.NewStyle().Foreground(changeColor(m)).Render(change) + "\n")
+	}
+
+	if sd := d.Score; sd != nil {
+		line := fmt.Sprintf("  🏁 RigRank Score: %.1f → %.1f (%+.1f, %+.1f%%)", sd.Base, sd.Head, sd.Delta, sd.DeltaPct)
+		if !sd.Comparable {
+			line += "  [different formula versions]"
+		}
+		s.WriteString("\n" + lipgloss.NewStyle().Bold(true).Render(line) + "\n")
+	}
+	s.WriteString("\n" + lipgloss.NewStyle().Foreground(colorInfo).Render(fmt.Sprintf("  Significance: Welch's t-test on the recorded samples, p < %.2f.", d.Alpha)) + "\n")
+	return s.String()
+}
+
+// RenderDiffMarkdown renders a comparison of two runs as a Markdown table, e.g. for PR comments.
+func RenderDiffMarkdown(d *compare.Diff) string {
+	s := strings.Builder{}
+	s.WriteString("## RigRank Diff\n\n")
+	s.WriteString(fmt.Sprintf("- **Base:** `%s`\n- **Head:** `%s`\n", d.Base, d.Head))
+	if sd := d.Score; sd != nil {
+		s.WriteString(fmt.Sprintf("- **RigRank Score:** %.1f → %.1f (%+.1f, %+.1f%%)", sd.Base, sd.Head, sd.Delta, sd.DeltaPct))
+		if !sd.Comparable {
+			s.WriteString(" — different formula versions")
+		}
+		s.WriteString("\n")
+	}
+	for _, n := range d.Notes {
+		s.WriteString(fmt.Sprintf("- ⚠️ %s\n", n))
+	}
+
+	s.WriteString("\n| Profile | Metric | Base | Head | Delta | Delta % | Significance |\n")
+	s.WriteString("| :--- | :--- | ---: | ---: | ---: | ---: | :--- |\n")
+	for _, m := range d.Metrics {
+		s.WriteString(fmt.Sprintf("| %s | `%s` | %s | %s | %s | %s | %s |\n",
+			profileDisplayName(m.Profile), m.Metric, formatMean(m.Base), formatMean(m.Head), formatDelta(m), formatDeltaPct(m), significanceLabel(m)))
+	}
+	s.WriteString(fmt.Sprintf("\nSignificance: Welch's t-test on the recorded samples, p < %.2f.\n", d.Alpha))
+	return s.String()
+}
+
+func formatMean(m *models.StatsMetric) string {
+	if m == nil {
+		return notAvailable
+	}
+	return fmt.Sprintf("%.1f", m.Mean)
+}
+
+func formatDelta(m compare.MetricDiff) string {
+	if m.Base == nil || m.Head == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f", m.Delta)
+}
+
+func formatDeltaPct(m compare.MetricDiff) string {
+	if m.Base == nil || m.Head == nil || m.Base.Mean == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", m.DeltaPct)
+}
+
+// significanceLabel says whether a change is real, noise, or can't be tested.
+func significanceLabel(m compare.MetricDiff) string {
+	switch {
+	case m.Change == compare.ChangeAdded || m.Change == compare.ChangeRemoved || m.Change == compare.ChangeUnchanged:
+		return m.Change
+	case m.PValue == nil:
+		return m.Change + " (untested: no sample variance)"
+	case m.Significant:
+		return fmt.Sprintf("%s (p=%.3f)", m.Change, *m.PValue)
+	}
+	return fmt.Sprintf("noise (p=%.2f)", *m.PValue)
+}
+
+func changeColor(m compare.MetricDiff) lipgloss.Color {
+	if !m.Significant {
+		return colorInfo
+	}
+	if m.Change == compare.ChangeRegressed {
+		return colorPoor
+	}
+	return colorExcellent
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rohanelukurthy/rig-rank/internal/history"
+)
+
+// RenderHistory lists stored runs, newest last so the latest result sits by the prompt.
+func RenderHistory(entries []history.Entry) string {
+	if len(entries) == 0 {
+		return lipgloss.NewStyle().Foreground(colorInfo).Render("  No runs in history yet. Run `rigrank run` to record one.") + "\n"
+	}
+
+	s := strings.Builder{}
+	header := fmt.Sprintf("  %-20s %-17s %-28s %-13s %-13s %s", "ID", "Date (UTC)", "Model", "Digest", "Hardware", "Score")
+	s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render(header) + "\n")
+
+	for _, e := range entries {
+		digest := e.ModelDigest
+		if len(digest) > 12 {
+			digest = digest[:12]
+		}
+		score := "-"
+		if e.Score > 0 {
+			score = fmt.Sprintf("%.1f", e.Score)
+		}
+		s.WriteString(fmt.Sprintf("  %-20s %-17s %-28s %-13s %-13s %s\n",
+			e.ID, e.Timestamp.Format("2006-01-02 15:04"), e.Model, digest, e.Fingerprint, score))
+	}
+
+	// Fingerprints are opaque, so spell out each distinct rig once
+	seen := make(map[string]bool)
+	var rigs []string
+	for _, e := range entries {
+		if e.Fingerprint != "" && !seen[e.Fingerprint] {
+			seen[e.Fingerprint] = true
+			rigs = append(rigs, fmt.Sprintf("  %s  %s", e.Fingerprint, e.Hardware))
+		}
+	}
+	if len(rigs) > 0 {
+		s.WriteString("\n" + lipgloss.NewStyle().Foreground(colorInfo).Render(strings.Join(rigs, "\n")) + "\n")
+	}
+	return s.String()
+}
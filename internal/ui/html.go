@@ -0,0 +1,284 @@
+package ui
+
+import (
+	_ "embed"
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/history"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+//go:embed report.html.tmpl
+var htmlTemplateText string
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{"duration": formatDuration}).Parse(htmlTemplateText))
+
+// useCaseNames maps use case JSON keys to the names shown in reports.
+var useCaseNames = map[string]string{
+	"quick_qa":      "Quick Q&A",
+	"coding":        "Coding",
+	"writing":       "Writing",
+	"summarization": "Summarization",
+	"data_analysis": "Data Analysis",
+}
+
+// SVG colours match the terminal palette.
+const (
+	svgBar    = "#5f87d7"
+	svgMedian = "#00d787"
+	svgMean   = "#ffd700"
+	svgP99    = "#d70000"
+	svgBand   = "#d0d8e8"
+	svgText   = "#333"
+	svgMuted  = "#888"
+)
+
+type htmlRun struct {
+	Model       string
+	Meta        []reportField
+	Hardware    []reportField
+	Score       *models.RigRankScore
+	Charts      []template.HTML
+	Spreads     []template.HTML
+	Ratings     []htmlRating
+	Verdict     string
+	Tasks       []models.TaskEstimate
+	Warnings    []string
+	Unavailable bool // No suitability report to show
+}
+
+type reportField struct {
+	Name, Value string
+}
+
+type htmlRating struct {
+	UseCase, Rating, Reason string
+}
+
+// RenderHTML renders one or more reports as a single self-contained HTML page with
+// inline SVG charts and CSS, so it can be mailed or attached to a wiki and opened offline.
+func RenderHTML(reports ...*models.FullReport) (string, error) {
+	title := "RigRank Report"
+	if len(reports) == 1 {
+		title += ": " + reports[0].InferenceResults.ModelMetadata.Name
+	}
+	data := struct {
+		Title     string
+		Generated string
+		Runs      []htmlRun
+	}{Title: title, Generated: time.Now().UTC().Format("2006-01-02 15:04 MST")}
+	for _, r := range reports {
+		data.Runs = append(data.Runs, newHTMLRun(r))
+	}
+
+	var s strings.Builder
+	if err := htmlTemplate.Execute(&s, data); err != nil {
+		return "", err
+	}
+	return s.String(), nil
+}
+
+func newHTMLRun(r *models.FullReport) htmlRun {
+	res := r.InferenceResults
+	run := htmlRun{Model: res.ModelMetadata.Name}
+
+	run.Meta = runFields(r)
+	run.Hardware = hardwareFields(r)
+
+	b := &res.Benchmarks
+	run.Charts = []template.HTML{
+		svgBarChart("Time to first token", "ms", "lower is better", b, "ttft_ms"),
+		svgBarChart("Generation speed", "tokens/s", "higher is better", b, "gen_tps"),
+		svgBarChart("Prompt processing", "tokens/s", "higher is better", b, "prompt_tps"),
+	}
+	run.Spreads = []template.HTML{
+		svgSpreadChart("Time to first token distribution", "ms", b, "ttft_ms"),
+		svgSpreadChart("Generation speed distribution", "tokens/s", b, "gen_tps"),
+	}
+
+	for _, p := range reportProfiles(b) {
+		for _, w := range p.stats.Warnings {
+			run.Warnings = append(run.Warnings, p.name+": "+w.Message)
+		}
+	}
+	if r.ResourceTimeline != nil {
+		for _, spike := range r.ResourceTimeline.Spikes {
+			run.Warnings = append(run.Warnings, fmt.Sprintf("%s #%d overlapped a resource spike: %s", spike.Profile, spike.Iteration, strings.Join(spike.Reasons, ", ")))
+		}
+	}
+
+	u := r.UseCaseSuitability
+	if u == nil {
+		run.Unavailable = true
+		return run
+	}
+	for _, key := range models.UseCaseKeys {
+		s := u.UseCase(key)
+		run.Ratings = append(run.Ratings, htmlRating{UseCase: useCaseNames[key], Rating: s.Rating, Reason: s.Reason})
+	}
+	run.Verdict = u.OverallVerdict
+	if u.Score != nil && u.Score.Composite > 0 {
+		run.Score = u.Score
+	}
+	run.Tasks = u.TaskEstimates
+	return run
+}
+
+// runFields describes the model and the run, skipping whatever the report didn't record.
+func runFields(r *models.FullReport) []reportField {
+	res := r.InferenceResults
+	meta := res.ModelMetadata
+	var fields []reportField
+
+	fields = appendField(fields, "Quantization", meta.Quantization)
+	if meta.SizeMB > 0 {
+		fields = appendField(fields, "Size", fmt.Sprintf("%d MB", meta.SizeMB))
+	}
+	fields = appendField(fields, "Digest", shortDigest(meta.Digest))
+	fields = appendField(fields, "Initial load", formatMs(res.InitialLoadMs))
+	fields = appendField(fields, "Steady-state load", formatMs(res.SteadyStateLoadMs))
+	if p := r.Provenance; p != nil {
+		fields = appendField(fields, "Run", p.RunID)
+		if !p.Timestamp.IsZero() {
+			fields = appendField(fields, "Started", p.Timestamp.Format("2006-01-02 15:04 MST"))
+		}
+		fields = appendField(fields, "RigRank", p.RigRank.Version)
+		fields = appendField(fields, "Ollama", p.OllamaVersion)
+	}
+	return fields
+}
+
+// hardwareFields summarises the machine the run was recorded on.
+func hardwareFields(r *models.FullReport) []reportField {
+	var fields []reportField
+
+	if sys := r.SystemInfo; sys != nil {
+		if p := r.Provenance; p != nil {
+			fields = appendField(fields, "Host", p.Host.Hostname)
+			fields = appendField(fields, "OS", strings.TrimSpace(p.Host.Platform+" "+p.Host.PlatformVersion))
+		}
+		fields = appendField(fields, "CPU", fmt.Sprintf("%s (%d cores / %d threads)", sys.CPU.Model, sys.CPU.CoresPhysical, sys.CPU.CoresLogical))
+		fields = appendField(fields, "RAM", fmt.Sprintf("%d MB %s", sys.RAM.TotalMB, sys.RAM.Type))
+		gpu := sys.GPU.Model
+		if sys.GPU.VRAMTotalMB > 0 {
+			gpu += fmt.Sprintf(" (%d MB VRAM)", sys.GPU.VRAMTotalMB)
+		}
+		fields = appendField(fields, "GPU", gpu)
+		fields = appendField(fields, "Architecture", sys.Arch)
+		fields = appendField(fields, "Fingerprint", history.Fingerprint(sys))
+	}
+	return fields
+}
+
+// appendField skips empty values so old reports don't show blank rows.
+func appendField(fields []reportField, name, value string) []reportField {
+	if strings.TrimSpace(value) == "" {
+		return fields
+	}
+	return append(fields, reportField{name, value})
+}
+
+func shortDigest(d string) string {
+	if len(d) > 12 {
+		return d[:12]
+	}
+	return d
+}
+
+const (
+	svgWidth     = 560
+	svgLabelW    = 120
+	svgValueW    = 90
+	svgRowH      = 26
+	svgTopMargin = 34
+)
+
+// svgBarChart draws each profile's mean as a horizontal bar.
+func svgBarChart(title, unit, hint string, b *models.Benchmarks, metric string) template.HTML {
+	profiles := reportProfiles(b)
+	var max float64
+	for _, p := range profiles {
+		if m := p.stats.Stats.Metric(metric); m != nil {
+			max = math.Max(max, m.Mean)
+		}
+	}
+
+	height := svgTopMargin + len(profiles)*svgRowH + 8
+	plotW := float64(svgWidth - svgLabelW - svgValueW)
+	s := strings.Builder{}
+	fmt.Fprintf(&s, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" role="img" aria-label="%s">`, svgWidth, height, svgWidth, height, html.EscapeString(title))
+	fmt.Fprintf(&s, `<text x="0" y="16" font-size="14" font-weight="bold" fill="%s">%s</text>`, svgText, html.EscapeString(title))
+	fmt.Fprintf(&s, `<text x="%d" y="16" font-size="11" text-anchor="end" fill="%s">%s, %s</text>`, svgWidth, svgMuted, html.EscapeString(unit), hint)
+	for i, p := range profiles {
+		y := svgTopMargin + i*svgRowH
+		fmt.Fprintf(&s, `<text x="0" y="%d" font-size="12" fill="%s">%s</text>`, y+15, svgText, html.EscapeString(p.name))
+		m := p.stats.Stats.Metric(metric)
+		if m == nil || max <= 0 {
+			fmt.Fprintf(&s, `<text x="%d" y="%d" font-size="12" fill="%s">%s</text>`, svgLabelW, y+15, svgMuted, notAvailable)
+			continue
+		}
+		w := plotW * m.Mean / max
+		fmt.Fprintf(&s, `<rect x="%d" y="%d" width="%.1f" height="18" rx="3" fill="%s"><title>%s: %.1f %s</title></rect>`,
+			svgLabelW, y+2, w, svgBar, html.EscapeString(p.name), m.Mean, html.EscapeString(unit))
+		fmt.Fprintf(&s, `<text x="%.1f" y="%d" font-size="12" fill="%s">%.1f</text>`, float64(svgLabelW)+w+6, y+15, svgText, m.Mean)
+	}
+	s.WriteString(`</svg>`)
+	return template.HTML(s.String())
+}
+
+// svgSpreadChart shows each profile's median, mean and p99 on a shared axis, with the
+// mean ± one standard deviation shaded when the spread was recorded.
+func svgSpreadChart(title, unit string, b *models.Benchmarks, metric string) template.HTML {
+	profiles := reportProfiles(b)
+	var max float64
+	for _, p := range profiles {
+		if m := p.stats.Stats.Metric(metric); m != nil {
+			max = math.Max(max, m.P99)
+			if m.StdDev != nil {
+				max = math.Max(max, m.Mean+*m.StdDev)
+			}
+		}
+	}
+
+	height := svgTopMargin + len(profiles)*svgRowH + 30
+	plotW := float64(svgWidth - svgLabelW - 20)
+	x := func(v float64) float64 { return float64(svgLabelW) + plotW*math.Max(v, 0)/max }
+
+	s := strings.Builder{}
+	fmt.Fprintf(&s, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" role="img" aria-label="%s">`, svgWidth, height, svgWidth, height, html.EscapeString(title))
+	fmt.Fprintf(&s, `<text x="0" y="16" font-size="14" font-weight="bold" fill="%s">%s</text>`, svgText, html.EscapeString(title))
+	fmt.Fprintf(&s, `<text x="%d" y="16" font-size="11" text-anchor="end" fill="%s">%s</text>`, svgWidth, svgMuted, html.EscapeString(unit))
+	for i, p := range profiles {
+		y := svgTopMargin + i*svgRowH
+		mid := y + 11
+		fmt.Fprintf(&s, `<text x="0" y="%d" font-size="12" fill="%s">%s</text>`, y+15, svgText, html.EscapeString(p.name))
+		m := p.stats.Stats.Metric(metric)
+		if m == nil || max <= 0 {
+			fmt.Fprintf(&s, `<text x="%d" y="%d" font-size="12" fill="%s">%s</text>`, svgLabelW, y+15, svgMuted, notAvailable)
+			continue
+		}
+		if m.StdDev != nil && *m.StdDev > 0 {
+			lo, hi := x(m.Mean-*m.StdDev), x(m.Mean+*m.StdDev)
+			fmt.Fprintf(&s, `<rect x="%.1f" y="%d" width="%.1f" height="14" fill="%s"><title>mean ± stddev: %.1f ± %.1f</title></rect>`, lo, mid-7, hi-lo, svgBand, m.Mean, *m.StdDev)
+		}
+		fmt.Fprintf(&s, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="%s" stroke-width="2"/>`, x(m.Median), mid, x(m.P99), mid, svgMuted)
+		fmt.Fprintf(&s, `<circle cx="%.1f" cy="%d" r="5" fill="%s"><title>median %.1f</title></circle>`, x(m.Median), mid, svgMedian, m.Median)
+		fmt.Fprintf(&s, `<rect x="%.1f" y="%d" width="8" height="8" transform="rotate(45 %.1f %d)" fill="%s"><title>mean %.1f</title></rect>`, x(m.Mean)-4, mid-4, x(m.Mean), mid, svgMean, m.Mean)
+		fmt.Fprintf(&s, `<line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="%s" stroke-width="3"><title>p99 %.1f</title></line>`, x(m.P99), mid-7, x(m.P99), mid+7, svgP99, m.P99)
+	}
+
+	// Axis and legend
+	axisY := svgTopMargin + len(profiles)*svgRowH + 4
+	fmt.Fprintf(&s, `<line x1="%d" y1="%d" x2="%.1f" y2="%d" stroke="%s"/>`, svgLabelW, axisY, float64(svgLabelW)+plotW, axisY, svgMuted)
+	fmt.Fprintf(&s, `<text x="%d" y="%d" font-size="10" fill="%s">0</text>`, svgLabelW, axisY+12, svgMuted)
+	fmt.Fprintf(&s, `<text x="%.1f" y="%d" font-size="10" text-anchor="end" fill="%s">%.0f</text>`, float64(svgLabelW)+plotW, axisY+12, svgMuted, max)
+	legend := fmt.Sprintf(`<tspan fill="%s">●</tspan> median  <tspan fill="%s">◆</tspan> mean  <tspan fill="%s">|</tspan> p99  <tspan fill="%s">■</tspan> ±1 stddev`, svgMedian, svgMean, svgP99, svgBand)
+	fmt.Fprintf(&s, `<text x="%d" y="%d" font-size="11" fill="%s">%s</text>`, svgLabelW+120, axisY+22, svgText, legend)
+	s.WriteString(`</svg>`)
+	return template.HTML(s.String())
+}
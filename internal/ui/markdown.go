@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+)
+
+// RenderMarkdown renders one or more reports as GitHub-flavoured Markdown for pull
+// requests and wikis. Several reports start with a side-by-side comparison. With
+// details, each run ends with its full statistics in a collapsible block.
+func RenderMarkdown(reports []*models.FullReport, details bool) string {
+	s := strings.Builder{}
+	heading := "##"
+	if len(reports) == 1 {
+		s.WriteString("## RigRank Report: " + mdCell(reports[0].InferenceResults.ModelMetadata.Name) + "\n")
+	} else {
+		s.WriteString("## RigRank Comparison\n")
+		writeMarkdownComparison(&s, reports)
+		heading = "###"
+	}
+	for _, r := range reports {
+		if len(reports) > 1 {
+			s.WriteString("\n" + heading + " " + mdCell(r.InferenceResults.ModelMetadata.Name) + "\n")
+		}
+		writeMarkdownRun(&s, r, heading+"#", details)
+	}
+	return s.String()
+}
+
+// writeMarkdownComparison puts the headline numbers and ratings of every run side by side.
+func writeMarkdownComparison(s *strings.Builder, reports []*models.FullReport) {
+	s.WriteString("\n| Model | Quantization | Score | TTFT (ms) | Gen (t/s) | Prompt (t/s) | Hardware |\n")
+	s.WriteString("| :--- | :--- | ---: | ---: | ---: | ---: | :--- |\n")
+	for _, r := range reports {
+		res := r.InferenceResults
+		b := &res.Benchmarks
+		hardware := "-"
+		if sys := r.SystemInfo; sys != nil {
+			hardware = sys.CPU.Model
+			if sys.GPU.Model != "" && sys.GPU.Model != sys.CPU.Model {
+				hardware += " / " + sys.GPU.Model
+			}
+		}
+		fmt.Fprintf(s, "| %s | %s | %s | %s | %s | %s | %s |\n",
+			mdCell(res.ModelMetadata.Name), mdCell(cmp.Or(res.ModelMetadata.Quantization, "-")), markdownScore(r),
+			formatMean(b.Atomic.Stats.TTFTMs), formatMean(b.CodeGen.Stats.GenTPS), formatMean(b.Summarization.Stats.PromptTPS),
+			mdCell(hardware))
+	}
+
+	s.WriteString("\n| Use case |")
+	for _, r := range reports {
+		s.WriteString(" " + mdCell(r.InferenceResults.ModelMetadata.Name) + " |")
+	}
+	s.WriteString("\n| :--- |" + strings.Repeat(" :---: |", len(reports)) + "\n")
+	for _, key := range models.UseCaseKeys {
+		s.WriteString("| " + useCaseNames[key] + " |")
+		for _, r := range reports {
+			rating := notAvailable
+			if u := r.UseCaseSuitability; u != nil {
+				rating = u.UseCase(key).Rating
+			}
+			s.WriteString(" " + rating + " |")
+		}
+		s.WriteString("\n")
+	}
+}
+
+func writeMarkdownRun(s *strings.Builder, r *models.FullReport, heading string, details bool) {
+	u := r.UseCaseSuitability
+	if u != nil && u.Score != nil && u.Score.Composite > 0 {
+		fmt.Fprintf(s, "\n🏁 **RigRank Score:** %.1f (formula v%s, 100 = reference rig)\n", u.Score.Composite, u.Score.FormulaVersion)
+		if u.Score.Partial {
+			fmt.Fprintf(s, "\n⚠️ Partial score: no data for %s\n", strings.Join(u.Score.Missing, ", "))
+		}
+	}
+
+	writeMarkdownFields(s, heading+" Run", runFields(r))
+	writeMarkdownFields(s, heading+" Hardware", hardwareFields(r))
+
+	b := &r.InferenceResults.Benchmarks
+	s.WriteString("\n" + heading + " Per-profile results\n\n")
+	s.WriteString("| Profile | TTFT (ms) | TTFT P99 (ms) | Gen (t/s) | Prompt (t/s) | Total (ms) |\n")
+	s.WriteString("| :--- | ---: | ---: | ---: | ---: | ---: |\n")
+	for _, p := range reportProfiles(b) {
+		st := &p.stats.Stats
+		ttftP99 := notAvailable
+		if st.TTFTMs != nil {
+			ttftP99 = fmt.Sprintf("%.1f", st.TTFTMs.P99)
+		}
+		fmt.Fprintf(s, "| %s | %s | %s | %s | %s | %s |\n",
+			p.name, formatMean(st.TTFTMs), ttftP99, formatMean(st.GenTPS), formatMean(st.PromptTPS), formatMean(st.TotalDurationMs))
+	}
+
+	var warnings []string
+	for _, p := range reportProfiles(b) {
+		for _, w := range p.stats.Warnings {
+			warnings = append(warnings, p.name+": "+w.Message)
+		}
+	}
+	if len(warnings) > 0 {
+		s.WriteString("\n")
+		for _, w := range warnings {
+			s.WriteString("> ⚠️ " + w + "\n")
+		}
+	}
+
+	if u == nil {
+		s.WriteString("\n_This report has no suitability ratings._\n")
+	} else {
+		s.WriteString("\n" + heading + " Suitability\n\n")
+		s.WriteString("| Use case | Rating | Reason |\n| :--- | :--- | :--- |\n")
+		for _, key := range models.UseCaseKeys {
+			rating := u.UseCase(key)
+			fmt.Fprintf(s, "| %s | **%s** | %s |\n", useCaseNames[key], rating.Rating, mdCell(rating.Reason))
+		}
+		if u.OverallVerdict != "" {
+			s.WriteString("\n**Verdict:** " + u.OverallVerdict + "\n")
+		}
+	}
+
+	if details {
+		writeMarkdownDetails(s, b)
+	}
+}
+
+func writeMarkdownFields(s *strings.Builder, title string, fields []reportField) {
+	if len(fields) == 0 {
+		return
+	}
+	s.WriteString("\n" + title + "\n\n| | |\n| :--- | :--- |\n")
+	for _, f := range fields {
+		fmt.Fprintf(s, "| %s | %s |\n", f.Name, mdCell(f.Value))
+	}
+}
+
+// writeMarkdownDetails lists every recorded statistic, and the per-iteration samples
+// when --raw-samples kept them, inside <details> blocks that GitHub and most wikis
+// render collapsed.
+func writeMarkdownDetails(s *strings.Builder, b *models.Benchmarks) {
+	s.WriteString("\n<details>\n<summary>Raw statistics</summary>\n\n")
+	s.WriteString("| Profile | Metric | Mean | Median | P99 | Std dev | Samples |\n")
+	s.WriteString("| :--- | :--- | ---: | ---: | ---: | ---: | ---: |\n")
+	for _, p := range reportProfiles(b) {
+		for _, key := range models.MetricKeys {
+			m := p.stats.Stats.Metric(key)
+			if m == nil {
+				continue
+			}
+			stddev, samples := "-", "-"
+			if m.StdDev != nil {
+				stddev = fmt.Sprintf("%.2f", *m.StdDev)
+			}
+			if m.N > 0 {
+				samples = fmt.Sprint(m.N)
+			}
+			fmt.Fprintf(s, "| %s | `%s` | %.2f | %.2f | %.2f | %s | %s |\n", p.name, key, m.Mean, m.Median, m.P99, stddev, samples)
+		}
+	}
+	s.WriteString("\n</details>\n")
+
+	var samples strings.Builder
+	for _, p := range reportProfiles(b) {
+		for _, r := range p.stats.RawSamples {
+			result := "ok"
+			if r.Error != "" {
+				result = mdCell(r.Error)
+			}
+			fmt.Fprintf(&samples, "| %s | %d | %.1f | %.1f | %.1f | %.1f | %d | %d | %s |\n", p.name, r.Iteration,
+				r.WallClockMs, r.LoadDurationMs, r.PromptEvalDurationMs, r.EvalDurationMs, r.PromptEvalCount, r.EvalCount, result)
+		}
+	}
+	if samples.Len() > 0 {
+		s.WriteString("\n<details>\n<summary>Raw samples</summary>\n\n")
+		s.WriteString("| Profile | Iteration | Wall clock (ms) | Load (ms) | Prompt eval (ms) | Eval (ms) | Prompt tokens | Output tokens | Result |\n")
+		s.WriteString("| :--- | ---: | ---: | ---: | ---: | ---: | ---: | ---: | :--- |\n")
+		s.WriteString(samples.String())
+		s.WriteString("\n</details>\n")
+	}
+}
+
+func markdownScore(r *models.FullReport) string {
+	if u := r.UseCaseSuitability; u != nil && u.Score != nil && u.Score.Composite > 0 {
+		return fmt.Sprintf("%.1f", u.Score.Composite)
+	}
+	return notAvailable
+}
+
+// mdCell keeps free text from breaking a table row.
+func mdCell(v string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(v)
+}
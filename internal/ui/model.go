@@ -1,9 +1,11 @@
 package ui
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -23,6 +25,10 @@ var (
 	infoStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("39")) // Blue
 )
 
+// errInterrupted ends the suite span when the user quits mid-run, so the trace shows
+// a cancelled suite rather than one that never finished.
+var errInterrupted = fmt.Errorf("run interrupted: %w", context.Canceled)
+
 type ValidationStep int
 
 const (
@@ -58,38 +64,95 @@ type Model struct {
 
 	// Final Report
 	suitability *models.SuitabilityReport
+	policy      *scoring.Policy
+	tasks       *scoring.TaskCatalogue
 
 	// Quiet State
 	quietWait      bool
 	quietCfg       telemetry.QuietStateConfig
 	quietStatusMsg string
 	quietUpdateCh  chan string
+
+	// Energy sampling
+	energy bool
+
+	// Thinking mode for the Reasoning profile
+	think bool
+
+	// Keep unaggregated per-iteration timings
+	rawSamples bool
+
+	// Resource timeline
+	timelineInterval time.Duration
+	sampler          *telemetry.ResourceSampler
+	timeline         *models.ResourceTimeline
+
+	// Where and how this run was produced
+	provenance *models.Provenance
+}
+
+// Options configures a benchmark run driven by the TUI.
+type Options struct {
+	ModelName     string
+	Debug         bool
+	OutputPath    string
+	ContextWindow int
+	QuietWait     bool
+	QuietCfg      telemetry.QuietStateConfig
+	Energy        bool                   // Sample RAPL / nvidia-smi power draw while each profile runs
+	TimelineEvery time.Duration          // Resource timeline sampling interval; 0 disables it
+	Think         bool                   // Enable thinking mode on the Reasoning profile
+	RawSamples    bool                   // Keep every iteration's raw timings in the JSON output
+	Policy        *scoring.Policy        // Scoring thresholds; nil uses the embedded default
+	Tasks         *scoring.TaskCatalogue // Task-time estimates; nil uses the embedded default
+	Provenance    *models.Provenance     // Build and command-line context; the run adds its ID, host and Ollama version
+}
+
+func profileNames(profiles []benchmark.ProfileConfig) []string {
+	names := make([]string, len(profiles))
+	for i, p := range profiles {
+		names[i] = p.Name
+	}
+	return names
 }
 
-func NewModel(modelName string, debug bool, outputPath string, contextWindow int, quietWait bool, quietCfg telemetry.QuietStateConfig) Model {
+func NewModel(opts Options) Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 
 	m := Model{
 		spinner:           s,
-		modelName:         modelName,
-		debug:             debug,
-		outputPath:        outputPath,
-		contextWindow:     contextWindow,
-		quietWait:         quietWait,
-		quietCfg:          quietCfg,
+		modelName:         opts.ModelName,
+		debug:             opts.Debug,
+		outputPath:        opts.OutputPath,
+		contextWindow:     opts.ContextWindow,
+		quietWait:         opts.QuietWait,
+		quietCfg:          opts.QuietCfg,
+		energy:            opts.Energy,
+		timelineInterval:  opts.TimelineEvery,
 		quietStatusMsg:    "Initializing quiet state monitoring...",
 		quietUpdateCh:     make(chan string),
 		step:              StepQuietState,
-		benchmarkProfiles: []string{"Atomic Check", "Code Generation", "Story Generation", "Summarization", "Reasoning"},
+		benchmarkProfiles: profileNames(benchmark.DefaultProfiles(opts.Think)),
+		think:             opts.Think,
+		rawSamples:        opts.RawSamples,
+		policy:            opts.Policy,
+		tasks:             opts.Tasks,
+		provenance:        newProvenance(opts.Provenance),
 		results: &models.BenchmarkResult{
-			MetricsVersion: "1.0",
-			ModelMetadata:  models.ModelMetadata{Name: modelName},
+			MetricsVersion: models.MetricsVersion,
+			ModelMetadata:  models.ModelMetadata{Name: opts.ModelName},
 		},
 	}
 
-	if !quietWait {
+	if m.policy == nil {
+		m.policy = scoring.DefaultPolicy()
+	}
+	if m.tasks == nil {
+		m.tasks = scoring.DefaultTasks()
+	}
+	if !opts.QuietWait {
 		m.step = StepTelemetry
 	}
 	return m
@@ -116,8 +179,10 @@ type telemetryMsg struct {
 }
 
 type healthCheckMsg struct {
-	client benchmark.BenchmarkClient
-	err    error
+	client   benchmark.BenchmarkClient
+	metadata *models.ModelMetadata // nil when the model isn't in the local listing
+	version  string                // Ollama server version; empty when unknown
+	err      error
 }
 
 type benchmarkProfileMsg struct {
@@ -137,6 +202,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if msg.Type == tea.KeyCtrlC {
+			if m.runner != nil {
+				m.runner.EndSuite(errInterrupted)
+			}
+			m.stopSampling()
 			return m, tea.Quit
 		}
 
@@ -168,7 +237,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.sysInfo = msg.info
 		m.step = StepHealthCheck
-		return m, checkHealthCmd()
+		return m, checkHealthCmd(m.modelName)
 
 	case healthCheckMsg:
 		if msg.err != nil {
@@ -176,36 +245,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 		m.client = msg.client
-		m.runner = benchmark.NewRunner(m.client, m.contextWindow)
-		m.runner.Debug = m.debug
+		if msg.metadata != nil {
+			m.results.ModelMetadata = *msg.metadata
+			m.results.ModelMetadata.Name = m.modelName
+		}
+		m.provenance.OllamaVersion = msg.version
+		m.runner = newRunner(m.client, Options{
+			ContextWindow: m.contextWindow,
+			Debug:         m.debug,
+			Think:         m.think,
+			Energy:        m.energy,
+			RawSamples:    m.rawSamples,
+		})
+		m.runner.StartSuite(m.modelName)
+		if m.timelineInterval > 0 {
+			m.sampler = telemetry.NewResourceSampler(m.timelineInterval)
+			m.sampler.Start()
+		}
 		m.step = StepBenchmark
 		return m, startNextProfileCmd(m.runner, m.modelName, m.benchmarkProfileIndex)
 
 	case benchmarkProfileMsg:
 		if msg.err != nil {
 			m.err = msg.err
+			m.runner.EndSuite(msg.err)
+			m.stopSampling()
 			return m, tea.Quit
 		}
 		// Store results
-		// Mapping back based on index or name is a bit fragile, but efficient for this 1-shot tool.
-		switch msg.profileName {
-		case "Atomic Check":
-			m.results.Benchmarks.Atomic = *msg.stats
-		case "Code Generation":
-			m.results.Benchmarks.CodeGen = *msg.stats
-		case "Story Generation":
-			m.results.Benchmarks.StoryGen = *msg.stats
-		case "Summarization":
-			m.results.Benchmarks.Summarization = *msg.stats
-		case "Reasoning":
-			m.results.Benchmarks.Reasoning = *msg.stats
-		}
+		benchmark.StoreProfile(&m.results.Benchmarks, msg.profileName, msg.stats)
 
 		m.benchmarkProfileIndex++
 		if m.benchmarkProfileIndex >= len(m.benchmarkProfiles) {
 			m.step = StepDone
+			m.runner.EndSuite(nil)
+			m.results.TokenDensity = benchmark.MeasureDensity(&m.results.Benchmarks)
+			m.stopSampling()
 			// Run scoring
-			m.suitability = scoring.Evaluate(m.results)
+			m.suitability = scoreResults(m.results, m.policy, m.tasks)
 
 			// If file output is requested, we might want to do it here or in FinalOutput
 			// Let's do it in FinalOutput to keep View pure-ish, but the command runner handles file writing usually.
@@ -220,29 +297,48 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m Model) FinalOutput() (string, []byte) {
-	if m.step != StepDone {
-		return "", nil
+// stopSampling ends the resource timeline, if one is being recorded, keeping what
+// was sampled so far. Every quit path after the sampler starts must call it.
+func (m *Model) stopSampling() {
+	if m.sampler == nil {
+		return
 	}
+	m.timeline = stopTimeline(m.sampler, m.runner)
+	m.sampler = nil
+}
 
-	// Create full report
-	fullReport := models.FullReport{
+// Report returns the full report of a finished run, or nil if the run didn't complete.
+func (m Model) Report() *models.FullReport {
+	if m.step != StepDone {
+		return nil
+	}
+	return &models.FullReport{
 		SystemInfo:         m.sysInfo,
 		InferenceResults:   m.results,
 		UseCaseSuitability: m.suitability,
+		ResourceTimeline:   m.timeline,
+		Provenance:         m.provenance,
+	}
+}
+
+func (m Model) FinalOutput() (string, []byte) {
+	fullReport := m.Report()
+	if fullReport == nil {
+		return "", nil
 	}
 
 	jsonBytes, _ := json.MarshalIndent(fullReport, "", "  ")
 
 	// Interactive Output (Visuals) - Always Chart
-	view := RenderChart(m.suitability, m.results)
+	view := RenderChart(m.suitability, m.results) + RenderResourceSpikes(m.timeline)
 
 	return view, jsonBytes
 }
 
 func (m Model) View() string {
 	if m.err != nil {
-		return fmt.Sprintf("\n%s Error: %v\n\n", crossMark, m.err)
+		// Spikes recorded before the failure may explain it
+		return fmt.Sprintf("\n%s Error: %v\n%s\n", crossMark, m.err, RenderResourceSpikes(m.timeline))
 	}
 
 	s := strings.Builder{}
@@ -336,63 +432,20 @@ func gatherTelemetryCmd() tea.Cmd {
 	}
 }
 
-func checkHealthCmd() tea.Cmd {
+func checkHealthCmd(modelName string) tea.Cmd {
 	return func() tea.Msg {
-		client := benchmark.NewClient("http://localhost:11434")
-		err := client.CheckHealth()
-		return healthCheckMsg{client: client, err: err}
+		client := benchmark.NewClient(benchmark.DefaultOllamaURL)
+		if err := client.CheckHealth(); err != nil {
+			return healthCheckMsg{client: client, err: err}
+		}
+		return healthCheckMsg{client: client, metadata: lookupMetadata(client, modelName), version: ollamaVersion(client)}
 	}
 }
 
 func startNextProfileCmd(runner *benchmark.Runner, modelName string, index int) tea.Cmd {
 	return func() tea.Msg {
-		// Define configs map or switch
-		var cfg benchmark.ProfileConfig
-		// We could hardcode or switch.
-		// "Atomic Check", "Code Generation", "Story Generation", "Summarization", "Reasoning"
-
-		// NOTE: This logic duplicates the config in runner.go/RunSuite somewhat.
-		//Ideally we'd share this config. For now, we redefine for the UI flow.
-
-		switch index {
-		case 0:
-			cfg = benchmark.ProfileConfig{
-				Input: 32, Output: 16, Name: "Atomic Check", Iterations: 5,
-				Prompt: "What is the capital of France? Answer in one word.",
-			}
-		case 1:
-			cfg = benchmark.ProfileConfig{
-				Input: 80, Output: 256, Name: "Code Generation", Iterations: 5,
-				Prompt: "Write a Python function to find the second largest element in a list.",
-			}
-		case 2:
-			cfg = benchmark.ProfileConfig{
-				Input: 50, Output: 400, Name: "Story Generation", Iterations: 5,
-				Prompt: "Write a short story about a robot who discovers nature.",
-			}
-		case 3:
-			cfg = benchmark.ProfileConfig{
-				Input: 2048, Output: 128, Name: "Summarization", Iterations: 5,
-				Prompt: generateDummyText(2048) + " Summarize the above.",
-			}
-		case 4:
-			cfg = benchmark.ProfileConfig{
-				Input: 100, Output: 150, Name: "Reasoning", Iterations: 5,
-				Prompt: "Solve this math problem step by step: If x=2 and y=3, what is 2x + 3y?",
-			}
-		}
-
+		cfg := runner.Profiles()[index]
 		stats, _, err := runner.RunProfile(modelName, cfg)
 		return benchmarkProfileMsg{profileName: cfg.Name, stats: stats, err: err}
 	}
 }
-
-func generateDummyText(tokens int) string {
-	base := "The quick brown fox jumps over the lazy dog. "
-	needed := tokens * 4
-	var b string
-	for len(b) < needed {
-		b += base
-	}
-	return b[:needed]
-}
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rohanelukurthy/rig-rank/internal/benchmark"
+	"github.com/rohanelukurthy/rig-rank/internal/telemetry"
+)
+
+func TestUpdate_StopsSamplerOnQuit(t *testing.T) {
+	for name, msg := range map[string]tea.Msg{
+		"profile error": benchmarkProfileMsg{err: errors.New("model not found")},
+		"ctrl+c":        tea.KeyMsg{Type: tea.KeyCtrlC},
+	} {
+		m := Model{runner: benchmark.NewRunner(nil, 4096), sampler: telemetry.NewResourceSampler(10 * time.Millisecond)}
+		m.sampler.Start()
+
+		next, _ := m.Update(msg)
+		got := next.(Model)
+		if got.sampler != nil {
+			t.Errorf("%s: expected the sampler to be stopped", name)
+		}
+		if got.timeline == nil {
+			t.Errorf("%s: expected the partial timeline to be kept", name)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/rohanelukurthy/rig-rank/internal/benchmark"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/scoring"
+	"github.com/rohanelukurthy/rig-rank/internal/telemetry"
+)
+
+// newRunner builds a runner with the optional measurements the run asked for.
+func newRunner(client benchmark.BenchmarkClient, opts Options) *benchmark.Runner {
+	runner := benchmark.NewRunner(client, opts.ContextWindow)
+	runner.Debug = opts.Debug
+	runner.Think = opts.Think
+	runner.RawSamples = opts.RawSamples
+	if opts.Energy {
+		if meter := telemetry.NewEnergyMeter(); meter.Available() {
+			runner.Power = meter
+		}
+	}
+	return runner
+}
+
+// lookupMetadata finds the model in the local listing. It is best-effort: nil means
+// the listing failed or the model isn't installed, which the run itself reports.
+func lookupMetadata(client *benchmark.Client, modelName string) *models.ModelMetadata {
+	installed, err := client.ListModels()
+	if err != nil {
+		return nil
+	}
+	info := benchmark.FindModel(installed, modelName)
+	if info == nil {
+		return nil
+	}
+	meta := info.Metadata()
+	meta.Name = modelName
+	return &meta
+}
+
+// newProvenance starts the provenance record for one run, on top of the
+// command-line context the caller supplied.
+func newProvenance(base *models.Provenance) *models.Provenance {
+	p := &models.Provenance{}
+	if base != nil {
+		*p = *base
+	}
+	p.RunID = newRunID()
+	p.Timestamp = time.Now().UTC()
+	if p.Host.OS == "" {
+		p.Host = telemetry.GetHostInfo()
+	}
+	return p
+}
+
+// newRunID returns a random identifier that stays unique across machines. Should the
+// system's random source fail, the start time in nanoseconds stands in for it.
+func newRunID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
+// ollamaVersion asks the server for its version. It is best-effort: empty means unknown.
+func ollamaVersion(client *benchmark.Client) string {
+	v, err := client.Version()
+	if err != nil {
+		return ""
+	}
+	return v
+}
+
+// stopTimeline ends resource sampling and flags the iterations that overlapped spikes.
+func stopTimeline(sampler *telemetry.ResourceSampler, runner *benchmark.Runner) *models.ResourceTimeline {
+	if sampler == nil {
+		return nil
+	}
+	timeline := sampler.Stop()
+	timeline.Spikes = telemetry.DetectSpikes(timeline, runner.IterationWindows(), telemetry.DefaultSpikeThresholds())
+	return timeline
+}
+
+// scoreResults rates the results and adds the task-time estimates.
+func scoreResults(results *models.BenchmarkResult, policy *scoring.Policy, tasks *scoring.TaskCatalogue) *models.SuitabilityReport {
+	report := policy.Evaluate(results)
+	report.TaskEstimates = tasks.Estimate(results)
+	return report
+}
+
+// RunHeadless runs the same pipeline as the TUI without a terminal, writing plain
+// progress lines instead of a spinner. It is meant for CI jobs and scripts.
+func RunHeadless(opts Options, progress io.Writer) (*models.FullReport, error) {
+	policy, tasks := opts.Policy, opts.Tasks
+	if policy == nil {
+		policy = scoring.DefaultPolicy()
+	}
+	if tasks == nil {
+		tasks = scoring.DefaultTasks()
+	}
+
+	provenance := newProvenance(opts.Provenance)
+	if opts.QuietWait {
+		if err := telemetry.WaitForQuietState(opts.QuietCfg, func(status string) {
+			fmt.Fprintln(progress, status)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	sysInfo, err := telemetry.GetSystemInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather telemetry: %w", err)
+	}
+
+	client := benchmark.NewClient(benchmark.DefaultOllamaURL)
+	if err := client.CheckHealth(); err != nil {
+		return nil, fmt.Errorf("ollama is not reachable: %w", err)
+	}
+	provenance.OllamaVersion = ollamaVersion(client)
+	results := &models.BenchmarkResult{
+		MetricsVersion: models.MetricsVersion,
+		ModelMetadata:  models.ModelMetadata{Name: opts.ModelName},
+	}
+	if meta := lookupMetadata(client, opts.ModelName); meta != nil {
+		results.ModelMetadata = *meta
+	}
+
+	runner := newRunner(client, opts)
+	runner.StartSuite(opts.ModelName)
+	var sampler *telemetry.ResourceSampler
+	if opts.TimelineEvery > 0 {
+		sampler = telemetry.NewResourceSampler(opts.TimelineEvery)
+		sampler.Start()
+	}
+
+	profiles := runner.Profiles()
+	for i, cfg := range profiles {
+		fmt.Fprintf(progress, "Running Suite (%d/%d): %s\n", i+1, len(profiles), cfg.Name)
+		stats, _, err := runner.RunProfile(opts.ModelName, cfg)
+		if err != nil {
+			err = fmt.Errorf("%s profile failed: %w", cfg.Name, err)
+			runner.EndSuite(err)
+			stopTimeline(sampler, runner)
+			return nil, err
+		}
+		benchmark.StoreProfile(&results.Benchmarks, cfg.Name, stats)
+	}
+	runner.EndSuite(nil)
+	results.TokenDensity = benchmark.MeasureDensity(&results.Benchmarks)
+
+	return &models.FullReport{
+		SystemInfo:         sysInfo,
+		InferenceResults:   results,
+		UseCaseSuitability: scoreResults(results, policy, tasks),
+		ResourceTimeline:   stopTimeline(sampler, runner),
+		Provenance:         provenance,
+	}, nil
+}
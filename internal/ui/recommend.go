@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/recommend"
+)
+
+// RenderRecommendations renders the predicted fit of each candidate model. Scores holds
+// the suitability report of any candidate that was benchmarked, keyed by model name.
+func RenderRecommendations(budget recommend.Budget, candidates []recommend.Candidate, scores map[string]*models.SuitabilityReport) string {
+	s := strings.Builder{}
+
+	title := lipgloss.NewStyle().Foreground(colorTitle).Bold(true).Render("🧭 Model Recommendations")
+	s.WriteString("\n  " + title + "\n")
+
+	memory := fmt.Sprintf("GPU memory: %s  |  With CPU offload: %s", formatMB(budget.GPUMB), formatMB(budget.TotalMB))
+	if budget.Unified {
+		memory += "  (unified memory)"
+	}
+	s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render("  "+memory) + "\n\n")
+
+	header := fmt.Sprintf("  %-28s %-8s %-8s %-9s %-17s %s", "Model", "Params", "Quant", "Needs", "Fit", "Score")
+	s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render(header) + "\n")
+
+	for _, c := range candidates {
+		name := c.Name
+		if c.Installed {
+			name += " ✓"
+		}
+		fit := fitLabel(c)
+		score := "-"
+		if report, ok := scores[c.Name]; ok && report.Score != nil && report.Score.Composite > 0 {
+			score = fmt.Sprintf("%.1f", report.Score.Composite)
+		}
+		row := fmt.Sprintf("  %-28s %-8s %-8s %-9s ", name, c.ParameterSize, c.Quantization, formatMB(c.RequiredMB))
+		s.WriteString(row + lipgloss.NewStyle().Foreground(fitColor(c.Fit)).Render(fmt.Sprintf("%-17s", fit)) + " " + score + "\n")
+	}
+
+	s.WriteString("\n" + lipgloss.NewStyle().Foreground(colorInfo).Render("  ✓ = installed. Needs includes ~20% for KV cache and runtime buffers.") + "\n")
+	return s.String()
+}
+
+func fitLabel(c recommend.Candidate) string {
+	switch c.Fit {
+	case recommend.FitFullGPU:
+		return "Full GPU"
+	case recommend.FitPartialOffload:
+		if c.GPUFraction == 0 {
+			return "CPU only"
+		}
+		return fmt.Sprintf("Offload (%.0f%% GPU)", c.GPUFraction*100)
+	}
+	return "Won't fit"
+}
+
+func fitColor(fit string) lipgloss.Color {
+	switch fit {
+	case recommend.FitFullGPU:
+		return colorExcellent
+	case recommend.FitPartialOffload:
+		return colorGood
+	}
+	return colorPoor
+}
+
+func formatMB(mb int) string {
+	if mb >= 1024 {
+		return fmt.Sprintf("%.1fGB", float64(mb)/1024)
+	}
+	return fmt.Sprintf("%dMB", mb)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/scoring"
 )
 
 // --- Colors ---
@@ -18,15 +19,28 @@ var (
 	colorBorder    = lipgloss.Color("240") // Light grey for borders
 )
 
-// tpsToWords converts tokens/sec to approximate words/sec (tokens are ~1.3 per word on average)
-func tpsToWords(tps float64) string {
-	words := tps / 1.3
+// defaultTokensPerWord is used when the model's own density couldn't be measured
+const defaultTokensPerWord = 1.3
+
+// tpsToWords converts tokens/sec to approximate words/sec using the model's measured
+// tokens-per-word ratio.
+func tpsToWords(tps float64, tokensPerWord float64) string {
+	if tokensPerWord <= 0 {
+		tokensPerWord = defaultTokensPerWord
+	}
+	return formatWords(tps / tokensPerWord)
+}
+
+func formatWords(words float64) string {
 	if words >= 1000 {
 		return fmt.Sprintf("~%.1fk", words/1000)
 	}
 	return fmt.Sprintf("~%.0f", words)
 }
 
+// notAvailable is shown in place of a metric that has no valid samples
+const notAvailable = "N/A"
+
 // formatMs formats milliseconds for display
 func formatMs(ms float64) string {
 	return fmt.Sprintf("%.0fms", ms)
@@ -73,45 +87,232 @@ func RenderReportCard(report *models.SuitabilityReport, result *models.Benchmark
 	midBorder := borderStyle.Render("  ├────────────────────────────────────────────────────────────────────┤")
 	s.WriteString(midBorder + "\n")
 
+	tokensPerWord := defaultTokensPerWord
+	if result.TokenDensity != nil && result.TokenDensity.TokensPerWord > 0 {
+		tokensPerWord = result.TokenDensity.TokensPerWord
+	}
+
 	// Table rows
 	renderRow := func(name string, stats *models.Stats) string {
-		startup := formatMs(stats.TTFTMs.Mean)
-		writeSpeed := tpsToWords(stats.GenTPS.Mean) + " words/sec"
-		readSpeed := tpsToWords(stats.PromptTPS.Mean) + " words/sec"
+		startup := notAvailable
+		if stats.TTFTMs != nil {
+			startup = formatMs(stats.TTFTMs.Mean)
+		}
+		// Output words are counted directly; input speed is converted with the measured density
+		writeSpeed := notAvailable
+		if stats.WordsPerSec != nil {
+			writeSpeed = formatWords(stats.WordsPerSec.Mean) + " words/sec"
+		} else if stats.GenTPS != nil {
+			writeSpeed = tpsToWords(stats.GenTPS.Mean, tokensPerWord) + " words/sec"
+		}
+		readSpeed := notAvailable
+		if stats.PromptTPS != nil {
+			readSpeed = tpsToWords(stats.PromptTPS.Mean, tokensPerWord) + " words/sec"
+		}
 		return fmt.Sprintf("  │  %-15s %-12s %-16s %-18s │", name, startup, writeSpeed, readSpeed)
 	}
 
-	s.WriteString(renderRow("Atomic Check", &result.Benchmarks.Atomic.Stats) + "\n")
-	s.WriteString(renderRow("Code Gen", &result.Benchmarks.CodeGen.Stats) + "\n")
-	s.WriteString(renderRow("Story Gen", &result.Benchmarks.StoryGen.Stats) + "\n")
-	s.WriteString(renderRow("Summarization", &result.Benchmarks.Summarization.Stats) + "\n")
-	s.WriteString(renderRow("Reasoning", &result.Benchmarks.Reasoning.Stats) + "\n")
+	for _, p := range reportProfiles(&result.Benchmarks) {
+		s.WriteString(renderRow(p.name, &p.stats.Stats) + "\n")
+	}
 
 	bottomBorder := borderStyle.Render("  └────────────────────────────────────────────────────────────────────┘")
-	s.WriteString(bottomBorder + "\n\n")
+	s.WriteString(bottomBorder + "\n")
+	if d := result.TokenDensity; d != nil {
+		s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render(fmt.Sprintf("     Tokenizer: %.2f tokens/word, %.2f tokens/char (measured from this model's output)", d.TokensPerWord, d.TokensPerChar)) + "\n")
+	}
+	s.WriteString("\n")
+
+	s.WriteString(renderThroughput(&result.Benchmarks, result.TokenDensity))
+	s.WriteString(renderThinking(&result.Benchmarks))
+	s.WriteString(renderWarnings(&result.Benchmarks))
+	s.WriteString(renderEnergy(&result.Benchmarks))
+	s.WriteString(renderTaskEstimates(report.TaskEstimates))
 
 	// Summary insights
 	writingRating := report.Coding.Rating
 	startupRating := report.QuickQA.Rating
 
-	if writingRating == "EXCELLENT" || writingRating == "GOOD" {
+	if writingRating == scoring.RatingExcellent || writingRating == scoring.RatingGood {
 		s.WriteString(lipgloss.NewStyle().Foreground(colorExcellent).Render("  ✅ Writing Speed: Excellent across all tasks.") + "\n")
+	} else if writingRating == scoring.RatingInsufficientData {
+		s.WriteString(lipgloss.NewStyle().Foreground(colorGood).Render("  ⚠️  Writing Speed: Insufficient data - "+report.Coding.Reason) + "\n")
 	} else {
 		s.WriteString(lipgloss.NewStyle().Foreground(colorPoor).Render("  ⚠️  Writing Speed: May feel slow for long outputs.") + "\n")
 	}
 
-	if startupRating == "POOR" {
+	if startupRating == scoring.RatingInsufficientData {
+		s.WriteString(lipgloss.NewStyle().Foreground(colorGood).Render("  ⚠️  Startup: Insufficient data - "+report.QuickQA.Reason) + "\n")
+	} else if startupRating == scoring.RatingPoor {
 		s.WriteString(lipgloss.NewStyle().Foreground(colorGood).Render("  ⚠️  Startup: Noticeable pause before responses begin.") + "\n")
 	} else {
 		s.WriteString(lipgloss.NewStyle().Foreground(colorExcellent).Render("  ✅ Startup: Responses begin quickly.") + "\n")
 	}
 
+	// Composite score
+	if sc := report.Score; sc != nil && sc.Composite > 0 {
+		scoreLine := fmt.Sprintf("  🏁 RigRank Score: %.1f", sc.Composite)
+		s.WriteString("\n" + lipgloss.NewStyle().Foreground(colorTitle).Bold(true).Render(scoreLine))
+		s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render(fmt.Sprintf("  (formula v%s, 100 = reference rig)", sc.FormulaVersion)) + "\n")
+		if sc.Partial {
+			s.WriteString(lipgloss.NewStyle().Foreground(colorGood).Render("  ⚠️  Partial score: no data for "+strings.Join(sc.Missing, ", ")) + "\n")
+		}
+	}
+
 	// Overall verdict
 	s.WriteString("\n  " + lipgloss.NewStyle().Italic(true).Render(report.OverallVerdict) + "\n")
 
 	return s.String()
 }
 
+type namedProfile struct {
+	name  string
+	stats *models.ProfileStats
+}
+
+// reportProfiles lists the profiles in report-card order with their short display names.
+func reportProfiles(b *models.Benchmarks) []namedProfile {
+	return []namedProfile{
+		{"Atomic Check", &b.Atomic},
+		{"Code Gen", &b.CodeGen},
+		{"Story Gen", &b.StoryGen},
+		{"Summarization", &b.Summarization},
+		{"Reasoning", &b.Reasoning},
+	}
+}
+
+// renderThroughput shows each profile's generation speed in tokens and characters
+// per second. Characters don't depend on the tokenizer, so they compare across
+// models; when a profile has no measured chars/sec it is derived from the density.
+func renderThroughput(b *models.Benchmarks, density *models.TokenDensity) string {
+	s := strings.Builder{}
+	for _, p := range reportProfiles(b) {
+		stats := &p.stats.Stats
+		if stats.GenTPS == nil {
+			continue
+		}
+		chars := notAvailable
+		if stats.CharsPerSec != nil {
+			chars = fmt.Sprintf("%.0f", stats.CharsPerSec.Mean)
+		} else if density != nil && density.TokensPerChar > 0 {
+			chars = fmt.Sprintf("~%.0f", stats.GenTPS.Mean/density.TokensPerChar)
+		}
+		if s.Len() == 0 {
+			s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render("  🔤 Output throughput") + "\n")
+		}
+		s.WriteString(fmt.Sprintf("     %-15s %7.1f tokens/sec  %7s chars/sec\n", p.name, stats.GenTPS.Mean, chars))
+	}
+	if s.Len() > 0 {
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// renderThinking shows how long a thinking model reasons before the user sees an answer.
+func renderThinking(b *models.Benchmarks) string {
+	s := strings.Builder{}
+	for _, p := range reportProfiles(b) {
+		t := p.stats.Thinking
+		if t == nil || t.TimeToFirstAnswerMs == nil {
+			continue
+		}
+		if s.Len() == 0 {
+			s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render("  🧠 Thinking (reasoning hidden from the user before the answer)") + "\n")
+		}
+		line := fmt.Sprintf("     %-15s first answer after %s", p.name, formatMs(t.TimeToFirstAnswerMs.Mean))
+		if t.ThinkingTokens != nil && t.AnswerTokens != nil {
+			line += fmt.Sprintf("  |  %.0f thinking / %.0f answer tokens", t.ThinkingTokens.Mean, t.AnswerTokens.Mean)
+		}
+		if t.ThinkingTPS != nil && t.AnswerTPS != nil {
+			line += fmt.Sprintf("  |  %.1f / %.1f t/s", t.ThinkingTPS.Mean, t.AnswerTPS.Mean)
+		}
+		s.WriteString(line + "\n")
+	}
+	if s.Len() > 0 {
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// renderWarnings lists validity problems so untrustworthy rows aren't taken at face value.
+func renderWarnings(b *models.Benchmarks) string {
+	s := strings.Builder{}
+	for _, p := range reportProfiles(b) {
+		for _, w := range p.stats.Warnings {
+			if s.Len() == 0 {
+				s.WriteString(lipgloss.NewStyle().Foreground(colorGood).Render("  ⚠️  Run validity warnings:") + "\n")
+			}
+			s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render(fmt.Sprintf("     %s: %s", p.name, w.Message)) + "\n")
+		}
+	}
+	if s.Len() > 0 {
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// renderEnergy lists per-profile efficiency when power sampling produced data.
+func renderEnergy(b *models.Benchmarks) string {
+	s := strings.Builder{}
+	for _, p := range reportProfiles(b) {
+		e := p.stats.Energy
+		if e == nil {
+			continue
+		}
+		if s.Len() == 0 {
+			s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render("  ⚡ Efficiency (energy per 1k generated tokens, average draw)") + "\n")
+		}
+		s.WriteString(fmt.Sprintf("     %-15s %8.1f J/1k tok  %7.1f W  (%s)\n", p.name, e.JoulesPer1kTokens, e.AvgWatts, strings.Join(e.Sources, "+")))
+	}
+	if s.Len() > 0 {
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// renderTaskEstimates translates the measured speeds into how long everyday tasks take.
+func renderTaskEstimates(estimates []models.TaskEstimate) string {
+	if len(estimates) == 0 {
+		return ""
+	}
+	s := strings.Builder{}
+	s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render("  ⏱️  Task times (first token + reading the prompt + writing the answer)") + "\n")
+	for _, e := range estimates {
+		if e.Unavailable != "" {
+			s.WriteString(fmt.Sprintf("     %-36s %8s  %s\n", e.Description, notAvailable, lipgloss.NewStyle().Foreground(colorInfo).Render("("+e.Unavailable+")")))
+			continue
+		}
+		s.WriteString(fmt.Sprintf("     %-36s %8s\n", e.Description, formatDuration(e.TotalMs)))
+	}
+	s.WriteString("\n")
+	return s.String()
+}
+
+// formatDuration formats milliseconds as the unit a person would use for a task.
+func formatDuration(ms float64) string {
+	switch {
+	case ms < 1000:
+		return formatMs(ms)
+	case ms < 60_000:
+		return fmt.Sprintf("%.1fs", ms/1000)
+	}
+	secs := int(ms/1000 + 0.5)
+	return fmt.Sprintf("%dm %02ds", secs/60, secs%60)
+}
+
+// RenderResourceSpikes lists iterations that ran while the host was disturbed.
+func RenderResourceSpikes(timeline *models.ResourceTimeline) string {
+	if timeline == nil || len(timeline.Spikes) == 0 {
+		return ""
+	}
+	s := strings.Builder{}
+	s.WriteString("\n" + lipgloss.NewStyle().Foreground(colorGood).Render(fmt.Sprintf("  ⚠️  Resource spikes overlapped %d iteration(s); treat these results with care:", len(timeline.Spikes))) + "\n")
+	for _, spike := range timeline.Spikes {
+		s.WriteString(lipgloss.NewStyle().Foreground(colorInfo).Render(fmt.Sprintf("     %s #%d: %s", spike.Profile, spike.Iteration, strings.Join(spike.Reasons, ", "))) + "\n")
+	}
+	return s.String()
+}
+
 // RenderChart is kept for backwards compatibility but now calls RenderReportCard
 func RenderChart(report *models.SuitabilityReport, result *models.BenchmarkResult) string {
 	return RenderReportCard(report, result, result.ModelMetadata.Name)
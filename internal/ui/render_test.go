@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rohanelukurthy/rig-rank/internal/models"
+	"github.com/rohanelukurthy/rig-rank/internal/scoring"
+)
+
+func TestRenderReportCard_MissingMetrics(t *testing.T) {
+	result := &models.BenchmarkResult{ModelMetadata: models.ModelMetadata{Name: "llama3"}}
+	result.Benchmarks.Atomic.Stats.TTFTMs = &models.StatsMetric{Mean: 40}
+	// Every other metric is nil, as after cached prompts or failed iterations
+
+	report := scoring.Evaluate(result)
+	card := RenderReportCard(report, result, "llama3")
+
+	if !strings.Contains(card, notAvailable) {
+		t.Errorf("Expected N/A cells for missing metrics, got:\n%s", card)
+	}
+	if !strings.Contains(card, "Insufficient data") {
+		t.Errorf("Expected insufficient data to be called out, got:\n%s", card)
+	}
+}
+
+func TestRenderReportCard_CharsPerSec(t *testing.T) {
+	result := &models.BenchmarkResult{ModelMetadata: models.ModelMetadata{Name: "llama3"}}
+	result.Benchmarks.CodeGen.Stats.GenTPS = &models.StatsMetric{Mean: 40}
+	result.Benchmarks.CodeGen.Stats.CharsPerSec = &models.StatsMetric{Mean: 150}
+	result.Benchmarks.StoryGen.Stats.GenTPS = &models.StatsMetric{Mean: 30}
+	result.TokenDensity = &models.TokenDensity{TokensPerChar: 0.25}
+
+	card := RenderReportCard(scoring.Evaluate(result), result, "llama3")
+	for _, want := range []string{"40.0 tokens/sec", "150 chars/sec", "30.0 tokens/sec", "~120 chars/sec"} {
+		if !strings.Contains(card, want) {
+			t.Errorf("Expected %q on the report card, got:\n%s", want, card)
+		}
+	}
+}
+
+func TestRenderReportCard_TaskEstimates(t *testing.T) {
+	result := &models.BenchmarkResult{ModelMetadata: models.ModelMetadata{Name: "llama3"}}
+	report := scoring.Evaluate(result)
+	report.TaskEstimates = []models.TaskEstimate{
+		{Description: "Write a 300-line source file", TotalMs: 75_400},
+		{Description: "Summarise a 10-page document", Unavailable: "no prompt_tps for summarization"},
+	}
+
+	card := RenderReportCard(report, result, "llama3")
+
+	if !strings.Contains(card, "1m 15s") {
+		t.Errorf("Expected the task time in minutes, got:\n%s", card)
+	}
+	if !strings.Contains(card, "no prompt_tps for summarization") {
+		t.Errorf("Expected the unavailable task to explain why, got:\n%s", card)
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	result := &models.BenchmarkResult{ModelMetadata: models.ModelMetadata{Name: "llama3 <q4>"}}
+	result.Benchmarks.Atomic.Stats.TTFTMs = &models.StatsMetric{Mean: 40, Median: 38, P99: 55}
+	result.Benchmarks.CodeGen.Stats.GenTPS = &models.StatsMetric{Mean: 42, Median: 41, P99: 45}
+	full := &models.FullReport{InferenceResults: result, UseCaseSuitability: scoring.Evaluate(result)}
+
+	page, err := RenderHTML(full)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(page, "<svg") {
+		t.Error("Expected inline SVG charts")
+	}
+	if !strings.Contains(page, "llama3 &lt;q4&gt;") || strings.Contains(page, "<q4>") {
+		t.Error("Expected the model name to be escaped")
+	}
+	if !strings.Contains(page, string(full.UseCaseSuitability.Coding.Rating)) {
+		t.Errorf("Expected the coding rating %s in the page", full.UseCaseSuitability.Coding.Rating)
+	}
+	if !strings.Contains(page, notAvailable) {
+		t.Error("Expected N/A for metrics the run never measured")
+	}
+	for _, external := range []string{`src="http`, `href="http`, "<script", "<link"} {
+		if strings.Contains(page, external) {
+			t.Errorf("Expected a self-contained page, found %s", external)
+		}
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	report := func(name string, ttft float64) *models.FullReport {
+		result := &models.BenchmarkResult{ModelMetadata: models.ModelMetadata{Name: name}}
+		result.Benchmarks.Atomic.Stats.TTFTMs = &models.StatsMetric{Mean: ttft, Median: ttft, P99: ttft + 10}
+		return &models.FullReport{InferenceResults: result, UseCaseSuitability: scoring.Evaluate(result)}
+	}
+
+	single := RenderMarkdown([]*models.FullReport{report("llama3|q4", 40)}, false)
+	if !strings.Contains(single, "## RigRank Report: llama3\\|q4") {
+		t.Errorf("Expected an escaped title, got:\n%s", single)
+	}
+	if !strings.Contains(single, "| Atomic Check | 40.0 | 50.0 |") {
+		t.Errorf("Expected the per-profile table, got:\n%s", single)
+	}
+	if strings.Contains(single, "Comparison") || strings.Contains(single, "<details>") {
+		t.Errorf("Expected a single run without comparison or details, got:\n%s", single)
+	}
+
+	both := RenderMarkdown([]*models.FullReport{report("llama3", 40), report("qwen3", 90)}, true)
+	for _, want := range []string{"## RigRank Comparison", "| Use case | llama3 | qwen3 |", "### qwen3", "<details>", "`ttft_ms`"} {
+		if !strings.Contains(both, want) {
+			t.Errorf("Expected %q in the comparison, got:\n%s", want, both)
+		}
+	}
+}